@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/SpechtLabs/CalendarAPI/pkg/api"
+	pb "github.com/SpechtLabs/CalendarAPI/pkg/protos"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spechtlabs/go-otel-utils/otelzap"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"gopkg.in/yaml.v3"
+)
+
+// availabilityOutput is what 'get availability' prints in the json and yaml output formats
+type availabilityOutput struct {
+	Availability *pb.Availability  `json:"availability" yaml:"availability"`
+	Next         *pb.CalendarEntry `json:"next,omitempty" yaml:"next,omitempty"`
+}
+
+var getAvailabilityCmd = &cobra.Command{
+	Use:     "availability [calendar_name]",
+	Example: "meetingepd get availability room",
+	Long:    "Show whether the calendar is busy or free, until when, and what's next",
+	Args:    cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		req := &pb.CalendarRequest{CalendarName: "all"}
+		if len(args) == 1 {
+			req.CalendarName = args[0]
+		}
+
+		addr := fmt.Sprintf("%s:%d", hostname, grpcPort)
+
+		conn, client := api.NewGrpcApiClient(addr)
+		defer func(conn *grpc.ClientConn) {
+			err := conn.Close()
+			if err != nil {
+				otelzap.L().Sugar().Errorw("failed to close gRPC connection", zap.Error(err))
+			}
+		}(conn)
+
+		// Contact the server
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		availability, err := client.GetAvailability(ctx, req)
+		if err != nil {
+			otelzap.L().Fatal(fmt.Sprintf("Failed to talk to gRPC API (%s) %v", addr, err))
+		}
+
+		next, err := client.GetNextEvent(ctx, req)
+		if err != nil {
+			otelzap.L().Fatal(fmt.Sprintf("Failed to talk to gRPC API (%s) %v", addr, err))
+		}
+
+		// gRPC can't return nil messages, so there is no next event if it's empty
+		if next.GetStart() == 0 {
+			next = nil
+		}
+
+		switch outFormat {
+		case "json":
+			json, err := json.Marshal(availabilityOutput{Availability: availability, Next: next})
+			if err != nil {
+				otelzap.L().Sugar().Error("failed to parse availability", zap.Error(err))
+			}
+			fmt.Println(string(json))
+
+		case "yaml":
+			yaml, err := yaml.Marshal(availabilityOutput{Availability: availability, Next: next})
+			if err != nil {
+				otelzap.L().Sugar().Error("failed to parse availability", zap.Error(err))
+			}
+			fmt.Println(string(yaml))
+
+		default:
+			fmt.Print(formatAvailabilityText(availability, next))
+		}
+	},
+}
+
+func formatAvailabilityText(availability *pb.Availability, next *pb.CalendarEntry) string {
+	// Styles
+	headerStyle := lipgloss.NewStyle().Bold(true).Underline(true)
+	contextStyle := lipgloss.NewStyle().Italic(true).Foreground(lipgloss.Color("#999999"))
+	busyStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FF0000")).Bold(true)
+	freeStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#00FF00")).Bold(true)
+
+	loc := displayLocation(availability.Timezone)
+	formatTime := func(unix int64) string {
+		t := time.Unix(unix, 0).In(loc)
+		if t.Format(time.DateOnly) != time.Now().In(loc).Format(time.DateOnly) {
+			return t.Format("Mon 02 Jan " + time.Kitchen)
+		}
+
+		return t.Format(time.Kitchen)
+	}
+
+	state := freeStyle.Render("free")
+	if availability.Busy {
+		state = busyStyle.Render("busy")
+	}
+
+	outStr := fmt.Sprintf("%s is %s", headerStyle.Render(availability.CalendarName), state)
+	if availability.Until != 0 {
+		outStr += fmt.Sprintf(" until %s", formatTime(availability.Until))
+	}
+	outStr += "\n"
+
+	if next != nil {
+		outStr += contextStyle.Render(fmt.Sprintf("    next: %s at %s", next.Title, formatTime(next.Start)))
+		outStr += "\n"
+	}
+
+	return outStr
+}
+
+func init() {
+	getAvailabilityCmd.Flags().StringVarP(&outFormat, "out", "o", "text", "Configure your output format (text, json, yaml)")
+
+	getCmd.AddCommand(getAvailabilityCmd)
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/SpechtLabs/CalendarAPI/pkg/api"
+	"github.com/SpechtLabs/CalendarAPI/pkg/client"
 	pb "github.com/SpechtLabs/CalendarAPI/pkg/protos"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/spechtlabs/go-otel-utils/otelzap"
@@ -16,14 +17,23 @@ import (
 	"gopkg.in/yaml.v3"
 )
 
-var outFormat string
+var (
+	outFormat string
+	fromTime  string
+	toTime    string
+)
 
 var clearCalendarCmd = &cobra.Command{
-	Use:     "calendar",
+	Use:     "calendar [calendar_name]",
 	Example: "meetingepd clear calendar",
-	Long:    "Clear the cache of the server and force it to fetch the latest info from the iCal",
-	Args:    cobra.ExactArgs(0),
+	Long:    "Clear the cache of the server and force it to fetch the latest info from the iCal of one or all calendars",
+	Args:    cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
+		calendarName := "all"
+		if len(args) == 1 {
+			calendarName = args[0]
+		}
+
 		addr := fmt.Sprintf("%s:%d", hostname, grpcPort)
 
 		conn, client := api.NewGrpcApiClient(addr)
@@ -38,12 +48,13 @@ var clearCalendarCmd = &cobra.Command{
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 
-		_, err := client.RefreshCalendar(ctx, &pb.CalendarRequest{CalendarName: "all"})
+		result, err := client.RefreshCalendar(ctx, &pb.CalendarRequest{CalendarName: calendarName})
 		if err != nil {
 			otelzap.L().Fatal(fmt.Sprintf("Failed to talk to gRPC API (%s) %v", addr, err))
 		}
 
-		fmt.Print("Cleared calendar cache\n")
+		fmt.Printf("Cleared cache of calendar %s\n\n", result.CalendarName)
+		fmt.Print(formatCalendarsText(&pb.ListCalendarsResponse{Calendars: result.Calendars}))
 	},
 }
 
@@ -57,6 +68,23 @@ var getCalendarCmd = &cobra.Command{
 			calendarName = args[0]
 		}
 
+		req := &pb.CalendarRequest{CalendarName: calendarName}
+		if fromTime != "" {
+			from, err := time.Parse(time.RFC3339, fromTime)
+			if err != nil {
+				otelzap.L().Fatal(fmt.Sprintf("Invalid --from time '%s': %v", fromTime, err))
+			}
+			req.From = from.Unix()
+		}
+
+		if toTime != "" {
+			to, err := time.Parse(time.RFC3339, toTime)
+			if err != nil {
+				otelzap.L().Fatal(fmt.Sprintf("Invalid --to time '%s': %v", toTime, err))
+			}
+			req.To = to.Unix()
+		}
+
 		addr := fmt.Sprintf("%s:%d", hostname, grpcPort)
 
 		conn, client := api.NewGrpcApiClient(addr)
@@ -71,7 +99,7 @@ var getCalendarCmd = &cobra.Command{
 		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 		defer cancel()
 
-		calendar, err := client.GetCalendar(ctx, &pb.CalendarRequest{CalendarName: calendarName})
+		calendar, err := client.GetCalendar(ctx, req)
 		if err != nil {
 			otelzap.L().Fatal(fmt.Sprintf("Failed to talk to gRPC API (%s) %v", addr, err))
 		}
@@ -91,6 +119,9 @@ var getCalendarCmd = &cobra.Command{
 			}
 			fmt.Println(string(yaml))
 
+		case "ics":
+			fmt.Print(formatCalendarICal(calendar))
+
 		default:
 			fmt.Println(formatText(calendar))
 		}
@@ -98,7 +129,7 @@ var getCalendarCmd = &cobra.Command{
 }
 
 func formatText(resp *pb.CalendarResponse) string {
-	now := time.Now()
+	now := time.Now().In(displayLocation(resp.Timezone))
 
 	// Styles
 	headerStyle := lipgloss.NewStyle().Bold(true).Underline(true)
@@ -111,16 +142,30 @@ func formatText(resp *pb.CalendarResponse) string {
 	strikeThroughStyle := lipgloss.NewStyle().Strikethrough(true).Foreground(lipgloss.Color("#666666"))
 
 	outStr := ""
-	outStr += contextStyle.Render(fmt.Sprintf("(last refreshed: %s)", time.Unix(resp.LastUpdated, 0).Format(time.TimeOnly)))
+	lastUpdated := time.Unix(resp.LastUpdated, 0).In(now.Location())
+	outStr += contextStyle.Render(fmt.Sprintf("(last refreshed: %s)", lastUpdated.Format(time.TimeOnly)))
 	outStr += "\n\n"
 
 	outStr += fmt.Sprintf("Calendar: %s Date: %s",
 		headerStyle.Render(resp.CalendarName),
-		headerStyle.Render(time.Unix(resp.LastUpdated, 0).Format(time.DateOnly)),
+		headerStyle.Render(lastUpdated.Format(time.DateOnly)),
 	)
 
+	if resp.Timezone != "" {
+		outStr += fmt.Sprintf(" Timezone: %s", headerStyle.Render(resp.Timezone))
+	}
+
 	outStr += "\n"
 
+	// Warn about calendars that could not be refreshed and show outdated events
+	for _, info := range resp.Calendars {
+		if info.Stale {
+			outStr += tentativeStyle.Render(fmt.Sprintf("Calendar %s could not be refreshed, showing events from %s",
+				info.Name, time.Unix(info.LastSuccess, 0).In(now.Location()).Format(time.DateTime)))
+			outStr += "\n"
+		}
+	}
+
 	// Separate all-day from timed events
 	allDayEntries := []*pb.CalendarEntry{}
 	normalEntries := []*pb.CalendarEntry{}
@@ -154,6 +199,20 @@ func formatText(resp *pb.CalendarResponse) string {
 	return outStr
 }
 
+// displayLocation returns the time zone to show times in, the local time zone if it's empty or unknown
+func displayLocation(timezone string) *time.Location {
+	if timezone == "" {
+		return time.Local
+	}
+
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return time.Local
+	}
+
+	return loc
+}
+
 func renderEntry(
 	item *pb.CalendarEntry,
 	idx int,
@@ -163,8 +222,17 @@ func renderEntry(
 	tentativeStyle, outOfOfficeStyle, defaultStyle,
 	contextStyle lipgloss.Style,
 ) string {
-	start := time.Unix(item.Start, 0)
-	end := time.Unix(item.End, 0)
+	// Events are shown in their calendar's time zone, which is pointed out if it differs from the response's
+	loc := now.Location()
+	zone := ""
+	if item.Timezone != "" && item.Timezone != loc.String() {
+		loc = displayLocation(item.Timezone)
+		now = now.In(loc)
+		zone = " " + loc.String()
+	}
+
+	start := time.Unix(item.Start, 0).In(loc)
+	end := time.Unix(item.End, 0).In(loc)
 
 	// Base line (without styling yet)
 	var line string
@@ -182,10 +250,19 @@ func renderEntry(
 		line += fmt.Sprintf("[%s]", item.Busy.String())
 	}
 
+	// Events that are not happening today are prefixed with their date
+	if start.Format(time.DateOnly) != now.Format(time.DateOnly) {
+		line += fmt.Sprintf("%s ", start.Format("Mon 02 Jan"))
+	}
+
 	if item.AllDay {
 		line += fmt.Sprintf("%s (all day)", item.Title)
 	} else {
-		line += fmt.Sprintf("%s: <%s - %s>", item.Title, start.Format(time.Kitchen), end.Format(time.Kitchen))
+		line += fmt.Sprintf("%s: <%s - %s%s>", item.Title, start.Format(time.Kitchen), end.Format(time.Kitchen), zone)
+	}
+
+	if len(item.Location) > 0 {
+		line += fmt.Sprintf(" @ %s", item.Location)
 	}
 
 	if len(item.Message) > 0 {
@@ -218,8 +295,15 @@ func renderEntry(
 	return line + "\n"
 }
 
+// formatCalendarICal renders the events as iCal feed, just like the server's /calendar.ics endpoint
+func formatCalendarICal(calendar *pb.CalendarResponse) string {
+	return string(client.MarshalCalendarICal(calendar))
+}
+
 func init() {
-	getCalendarCmd.Flags().StringVarP(&outFormat, "out", "o", "text", "Configure your output format (text, json, yaml)")
+	getCalendarCmd.Flags().StringVarP(&outFormat, "out", "o", "text", "Configure your output format (text, json, yaml, ics)")
+	getCalendarCmd.Flags().StringVar(&fromTime, "from", "", "Only show events after this time (RFC3339, defaults to the start of the cached window)")
+	getCalendarCmd.Flags().StringVar(&toTime, "to", "", "Only show events before this time (RFC3339, defaults to the end of the cached window)")
 
 	clearCmd.AddCommand(clearCalendarCmd)
 	getCmd.AddCommand(getCalendarCmd)
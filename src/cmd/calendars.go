@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/SpechtLabs/CalendarAPI/pkg/api"
+	pb "github.com/SpechtLabs/CalendarAPI/pkg/protos"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spechtlabs/go-otel-utils/otelzap"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"gopkg.in/yaml.v3"
+)
+
+var getCalendarsCmd = &cobra.Command{
+	Use:     "calendars",
+	Example: "meetingepd get calendars",
+	Long:    "List the configured calendars and the outcome of their last refresh",
+	Args:    cobra.ExactArgs(0),
+	Run: func(cmd *cobra.Command, args []string) {
+		addr := fmt.Sprintf("%s:%d", hostname, grpcPort)
+
+		conn, client := api.NewGrpcApiClient(addr)
+		defer func(conn *grpc.ClientConn) {
+			err := conn.Close()
+			if err != nil {
+				otelzap.L().Sugar().Errorw("failed to close gRPC connection", zap.Error(err))
+			}
+		}(conn)
+
+		// Contact the server
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		calendars, err := client.ListCalendars(ctx, &pb.ListCalendarsRequest{})
+		if err != nil {
+			otelzap.L().Fatal(fmt.Sprintf("Failed to talk to gRPC API (%s) %v", addr, err))
+		}
+
+		switch outFormat {
+		case "json":
+			json, err := json.Marshal(calendars)
+			if err != nil {
+				otelzap.L().Sugar().Error("failed to parse calendars", zap.Error(err))
+			}
+			fmt.Println(string(json))
+
+		case "yaml":
+			yaml, err := yaml.Marshal(calendars)
+			if err != nil {
+				otelzap.L().Sugar().Error("failed to parse calendars", zap.Error(err))
+			}
+			fmt.Println(string(yaml))
+
+		default:
+			fmt.Print(formatCalendarsText(calendars))
+		}
+	},
+}
+
+func formatCalendarsText(resp *pb.ListCalendarsResponse) string {
+	// Styles
+	headerStyle := lipgloss.NewStyle().Bold(true).Underline(true)
+	contextStyle := lipgloss.NewStyle().Italic(true).Foreground(lipgloss.Color("#999999"))
+	okStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#00FF00")).Bold(true)
+	staleStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFA500")).Bold(true)
+	errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FF0000")).Bold(true)
+
+	outStr := ""
+	for _, info := range resp.Calendars {
+		var state string
+		switch {
+		case info.LastError == "":
+			state = okStyle.Render("ok")
+		case info.Stale:
+			state = staleStyle.Render("stale")
+		default:
+			state = errorStyle.Render("failed")
+		}
+
+		outStr += fmt.Sprintf("%s (%s) %s: %d events\n", headerStyle.Render(info.Name), info.From, state, info.EventCount)
+		outStr += contextStyle.Render(fmt.Sprintf("    last refresh: %s (took %s)",
+			formatUnix(info.LastRefresh), time.Duration(info.Duration)*time.Millisecond))
+		outStr += "\n"
+		outStr += contextStyle.Render(fmt.Sprintf("    last success: %s", formatUnix(info.LastSuccess)))
+		outStr += "\n"
+
+		if info.LastError != "" {
+			outStr += errorStyle.Render(fmt.Sprintf("    last error: %s", info.LastError))
+			outStr += "\n"
+		}
+	}
+
+	return outStr
+}
+
+// formatUnix formats a unix timestamp for humans, zero timestamps are shown as 'never'
+func formatUnix(unix int64) string {
+	if unix == 0 {
+		return "never"
+	}
+
+	return time.Unix(unix, 0).Format(time.DateTime)
+}
+
+func init() {
+	getCalendarsCmd.Flags().StringVarP(&outFormat, "out", "o", "text", "Configure your output format (text, json, yaml)")
+
+	getCmd.AddCommand(getCalendarsCmd)
+}
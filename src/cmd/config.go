@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/SpechtLabs/CalendarAPI/pkg/client"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sierrasoftworks/humane-errors-go"
+	"github.com/spechtlabs/go-otel-utils/otelzap"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var configCmd = &cobra.Command{
+	Use:     "config",
+	Example: "calendarapi config",
+	Run: func(cmd *cobra.Command, args []string) {
+	},
+}
+
+var validateConfigCmd = &cobra.Command{
+	Use:     "validate",
+	Example: "calendarapi config validate -c config.yaml",
+	Long:    "Check the config file for mistakes, just like the server does on startup and before reloading it",
+	Args:    cobra.ExactArgs(0),
+	Run: func(cmd *cobra.Command, args []string) {
+		if viper.ConfigFileUsed() == "" {
+			otelzap.L().Fatal("No config file found, pass one with --config")
+		}
+
+		errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FF0000")).Bold(true)
+		warningStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFA500")).Bold(true)
+		okStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#00FF00")).Bold(true)
+		contextStyle := lipgloss.NewStyle().Italic(true).Foreground(lipgloss.Color("#999999"))
+
+		errs, warnings := client.ValidateConfig(viper.GetViper())
+		for _, err := range errs {
+			fmt.Printf("%s %s\n", errorStyle.Render("error:"), describeConfigIssue(err))
+			for _, advice := range err.Advice() {
+				fmt.Println(contextStyle.Render(fmt.Sprintf("    %s", advice)))
+			}
+		}
+
+		for _, warning := range warnings {
+			fmt.Printf("%s %s\n", warningStyle.Render("warning:"), describeConfigIssue(warning))
+			for _, advice := range warning.Advice() {
+				fmt.Println(contextStyle.Render(fmt.Sprintf("    %s", advice)))
+			}
+		}
+
+		if len(errs) > 0 {
+			fmt.Printf("%s is invalid: %d errors, %d warnings\n", viper.ConfigFileUsed(), len(errs), len(warnings))
+			os.Exit(1)
+		}
+
+		fmt.Printf("%s %s is valid (%d warnings)\n", okStyle.Render("ok:"), viper.ConfigFileUsed(), len(warnings))
+	},
+}
+
+// describeConfigIssue returns the message of the issue along with its cause
+func describeConfigIssue(err humane.Error) string {
+	if cause := err.Cause(); cause != nil {
+		return fmt.Sprintf("%s: %s", err.Error(), cause.Error())
+	}
+
+	return err.Error()
+}
+
+// logConfigIssues logs the problems ValidateConfig found and reports whether the configuration is valid
+func logConfigIssues(errs []humane.Error, warnings []humane.Error) bool {
+	for _, warning := range warnings {
+		otelzap.L().Sugar().Warnw("Questionable configuration", "warning", describeConfigIssue(warning), "advice", warning.Advice())
+	}
+
+	for _, err := range errs {
+		otelzap.L().Sugar().Errorw("Invalid configuration", "error", describeConfigIssue(err), "advice", err.Advice())
+	}
+
+	return len(errs) == 0
+}
+
+// readConfigCandidate reads the config file into a new viper instance, so it can be validated
+// before it replaces the active configuration
+func readConfigCandidate(file string) ([]byte, *viper.Viper, error) {
+	raw, err := os.ReadFile(file)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	configType := strings.TrimPrefix(filepath.Ext(file), ".")
+	if configType == "" {
+		configType = "yaml"
+	}
+
+	candidate := viper.New()
+	candidate.SetConfigType(configType)
+	candidate.SetEnvPrefix("CALAPI")
+	candidate.AutomaticEnv()
+
+	if err := candidate.ReadConfig(bytes.NewReader(raw)); err != nil {
+		return nil, nil, err
+	}
+
+	return raw, candidate, nil
+}
+
+func init() {
+	configCmd.AddCommand(validateConfigCmd)
+	rootCmd.AddCommand(configCmd)
+}
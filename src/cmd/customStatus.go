@@ -18,8 +18,71 @@ var (
 	description string
 	icon        string
 	iconSize    int32
+	validFrom   string
+	validUntil  string
 )
 
+// parseStatusTime parses the time of day (15:04) or RFC3339 time of a status' validity.
+// Times of day refer to today, or to the first day they are after the unix time 'after' if set.
+func parseStatusTime(value string, now time.Time, after int64) (int64, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.Unix(), nil
+	}
+
+	clock, err := time.ParseInLocation("15:04", value, now.Location())
+	if err != nil {
+		return 0, fmt.Errorf("'%s' is neither a time of day (15:04) nor a RFC3339 time", value)
+	}
+
+	t := time.Date(now.Year(), now.Month(), now.Day(), clock.Hour(), clock.Minute(), 0, 0, now.Location())
+	for after != 0 && t.Unix() <= after {
+		t = t.AddDate(0, 0, 1)
+	}
+
+	return t.Unix(), nil
+}
+
+// statusValidity resolves the --from and --until times of a status. Times of day that already
+// passed refer to tomorrow, so '--from 12:00 --until 13:00' set at 14:00 is shown tomorrow, and the
+// end is the first time of day after the start: a status until 02:00 valid from 22:00 ends tomorrow.
+func statusValidity(validFrom string, validUntil string, now time.Time) (int64, int64, error) {
+	// a status set at 12:00:30 to start at 12:00 starts right away rather than tomorrow
+	from, err := parseStatusTime(validFrom, now, now.Add(-time.Minute).Unix())
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid --from time: %w", err)
+	}
+
+	after := from
+	if after == 0 {
+		after = now.Unix()
+	}
+
+	until, err := parseStatusTime(validUntil, now, after)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid --until time: %w", err)
+	}
+
+	return from, until, nil
+}
+
+// formatValidity describes when the status is shown, or returns "" if it always is
+func formatValidity(status *pb.CustomStatus) string {
+	switch {
+	case status.ValidFrom != 0 && status.ValidUntil != 0:
+		return fmt.Sprintf("from %s until %s", formatUnix(status.ValidFrom), formatUnix(status.ValidUntil))
+	case status.ValidFrom != 0:
+		return fmt.Sprintf("from %s", formatUnix(status.ValidFrom))
+	case status.ValidUntil != 0:
+		return fmt.Sprintf("until %s", formatUnix(status.ValidUntil))
+	default:
+		return ""
+	}
+}
+
 var getCustomStatusCmd = &cobra.Command{
 	Use:     "status",
 	Example: "meetingepd get status",
@@ -50,6 +113,9 @@ var getCustomStatusCmd = &cobra.Command{
 			fmt.Printf("  - Title: %s\n", customStatus.Title)
 			fmt.Printf("  - Description: %s\n", customStatus.Description)
 			fmt.Printf("  - Icon: %s (%dx%d)\n", customStatus.Icon, customStatus.IconSize, customStatus.IconSize)
+			if validity := formatValidity(customStatus); validity != "" {
+				fmt.Printf("  - Valid: %s\n", validity)
+			}
 		} else {
 			fmt.Printf(" is not set\n")
 		}
@@ -58,9 +124,14 @@ var getCustomStatusCmd = &cobra.Command{
 
 var setCustomStatusCmd = &cobra.Command{
 	Use:     "status",
-	Example: "meetingepd set status",
+	Example: "meetingepd set status \"Lunch break\" --from 12:00 --until 13:00",
 	Args:    cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
+		from, until, err := statusValidity(validFrom, validUntil, time.Now())
+		if err != nil {
+			otelzap.L().Fatal(err.Error())
+		}
+
 		addr := fmt.Sprintf("%s:%d", hostname, grpcPort)
 
 		conn, client := api.NewGrpcApiClient(addr)
@@ -82,6 +153,8 @@ var setCustomStatusCmd = &cobra.Command{
 				Description: description,
 				Icon:        icon,
 				IconSize:    iconSize,
+				ValidFrom:   from,
+				ValidUntil:  until,
 			},
 		})
 		if err != nil {
@@ -94,6 +167,9 @@ var setCustomStatusCmd = &cobra.Command{
 			fmt.Printf("  - Title: %s\n", customStatus.Title)
 			fmt.Printf("  - Description: %s\n", customStatus.Description)
 			fmt.Printf("  - Icon: %s (%dx%d)\n", customStatus.Icon, customStatus.IconSize, customStatus.IconSize)
+			if validity := formatValidity(customStatus); validity != "" {
+				fmt.Printf("  - Valid: %s\n", validity)
+			}
 		} else {
 			fmt.Printf(" is not set\n")
 		}
@@ -132,6 +208,8 @@ func init() {
 	setCustomStatusCmd.Flags().StringVarP(&description, "description", "t", "", "Description of your custom status")
 	setCustomStatusCmd.Flags().StringVarP(&icon, "icon", "i", "warning_icon", "Icon to use in custom status")
 	setCustomStatusCmd.Flags().Int32Var(&iconSize, "icon_size", 196, "Icon size to display in the custom status")
+	setCustomStatusCmd.Flags().StringVar(&validFrom, "from", "", "Only show the custom status from this time on (15:04 or RFC3339)")
+	setCustomStatusCmd.Flags().StringVar(&validUntil, "until", "", "Hide the custom status after this time (15:04 or RFC3339)")
 
 	setCustomStatusCmd.Flags().StringVarP(&calendar, "calendar", "q", "", "Name of the calendar to set the custom status for")
 	_ = setCustomStatusCmd.MarkFlagRequired("calendar")
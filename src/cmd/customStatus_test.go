@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStatusValidity(t *testing.T) {
+	loc := time.FixedZone("CEST", 2*60*60)
+	at := func(day int, hour int, minute int) int64 {
+		return time.Date(2025, time.April, day, hour, minute, 0, 0, loc).Unix()
+	}
+
+	tests := []struct {
+		name      string
+		now       time.Time
+		from      string
+		until     string
+		wantFrom  int64
+		wantUntil int64
+	}{
+		{"later today", time.Date(2025, time.April, 1, 10, 0, 0, 0, loc), "12:00", "13:00", at(1, 12, 0), at(1, 13, 0)},
+		{"already passed", time.Date(2025, time.April, 1, 14, 0, 0, 0, loc), "12:00", "13:00", at(2, 12, 0), at(2, 13, 0)},
+		{"within the minute", time.Date(2025, time.April, 1, 12, 0, 30, 0, loc), "12:00", "13:00", at(1, 12, 0), at(1, 13, 0)},
+		{"over midnight", time.Date(2025, time.April, 1, 20, 0, 0, 0, loc), "22:00", "02:00", at(1, 22, 0), at(2, 2, 0)},
+		{"until only", time.Date(2025, time.April, 1, 14, 0, 0, 0, loc), "", "13:00", 0, at(2, 13, 0)},
+		{"from only", time.Date(2025, time.April, 1, 14, 0, 0, 0, loc), "09:00", "", at(2, 9, 0), 0},
+		{"RFC3339", time.Date(2025, time.April, 1, 14, 0, 0, 0, loc), "2025-04-01T12:00:00+02:00", "13:00", at(1, 12, 0), at(1, 13, 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, until, err := statusValidity(tt.from, tt.until, tt.now)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if from != tt.wantFrom || until != tt.wantUntil {
+				t.Errorf("got %s - %s, want %s - %s",
+					time.Unix(from, 0).In(loc), time.Unix(until, 0).In(loc),
+					time.Unix(tt.wantFrom, 0).In(loc), time.Unix(tt.wantUntil, 0).In(loc))
+			}
+		})
+	}
+
+	if _, _, err := statusValidity("noon", "", time.Now()); err == nil {
+		t.Error("expected an error for an invalid time")
+	}
+}
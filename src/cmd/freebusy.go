@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/SpechtLabs/CalendarAPI/pkg/api"
+	"github.com/SpechtLabs/CalendarAPI/pkg/client"
+	pb "github.com/SpechtLabs/CalendarAPI/pkg/protos"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spechtlabs/go-otel-utils/otelzap"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"gopkg.in/yaml.v3"
+)
+
+var getFreeBusyCmd = &cobra.Command{
+	Use:     "freebusy [calendar_name...]",
+	Example: "meetingepd get freebusy room-a room-b -o ics",
+	Long:    "Show when the calendars are busy, merged into a single timeline without any event details",
+	Run: func(cmd *cobra.Command, args []string) {
+		req := &pb.FreeBusyRequest{CalendarNames: args}
+		if fromTime != "" {
+			from, err := time.Parse(time.RFC3339, fromTime)
+			if err != nil {
+				otelzap.L().Fatal(fmt.Sprintf("Invalid --from time '%s': %v", fromTime, err))
+			}
+			req.From = from.Unix()
+		}
+
+		if toTime != "" {
+			to, err := time.Parse(time.RFC3339, toTime)
+			if err != nil {
+				otelzap.L().Fatal(fmt.Sprintf("Invalid --to time '%s': %v", toTime, err))
+			}
+			req.To = to.Unix()
+		}
+
+		addr := fmt.Sprintf("%s:%d", hostname, grpcPort)
+
+		conn, client := api.NewGrpcApiClient(addr)
+		defer func(conn *grpc.ClientConn) {
+			err := conn.Close()
+			if err != nil {
+				otelzap.L().Sugar().Errorw("failed to close gRPC connection", zap.Error(err))
+			}
+		}(conn)
+
+		// Contact the server
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		freeBusy, err := client.GetFreeBusy(ctx, req)
+		if err != nil {
+			otelzap.L().Fatal(fmt.Sprintf("Failed to talk to gRPC API (%s) %v", addr, err))
+		}
+
+		switch outFormat {
+		case "json":
+			json, err := json.Marshal(freeBusy)
+			if err != nil {
+				otelzap.L().Sugar().Error("failed to parse free/busy timeline", zap.Error(err))
+			}
+			fmt.Println(string(json))
+
+		case "yaml":
+			yaml, err := yaml.Marshal(freeBusy)
+			if err != nil {
+				otelzap.L().Sugar().Error("failed to parse free/busy timeline", zap.Error(err))
+			}
+			fmt.Println(string(yaml))
+
+		case "ics":
+			fmt.Print(formatFreeBusyICal(freeBusy))
+
+		default:
+			fmt.Print(formatFreeBusyText(freeBusy))
+		}
+	},
+}
+
+func formatFreeBusyText(freeBusy *pb.FreeBusyResponse) string {
+	// Styles
+	headerStyle := lipgloss.NewStyle().Bold(true).Underline(true)
+	contextStyle := lipgloss.NewStyle().Italic(true).Foreground(lipgloss.Color("#999999"))
+	tentativeStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFA500")).Italic(true)
+	outOfOfficeStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#800080")).Bold(true)
+	defaultStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF"))
+
+	outStr := fmt.Sprintf("%s\n", headerStyle.Render(strings.Join(freeBusy.CalendarNames, ", ")))
+	outStr += contextStyle.Render(fmt.Sprintf("(%s - %s)", formatUnix(freeBusy.From), formatUnix(freeBusy.To)))
+	outStr += "\n"
+
+	for _, interval := range freeBusy.Intervals {
+		line := fmt.Sprintf("%s - %s %s", formatUnix(interval.Start), formatUnix(interval.End), interval.Busy.String())
+
+		switch interval.Busy {
+		case pb.BusyState_Tentative:
+			line = tentativeStyle.Render(line)
+		case pb.BusyState_OutOfOffice, pb.BusyState_WorkingElsewhere:
+			line = outOfOfficeStyle.Render(line)
+		default:
+			line = defaultStyle.Render(line)
+		}
+
+		outStr += line + "\n"
+	}
+
+	return outStr
+}
+
+// formatFreeBusyICal renders the timeline as VFREEBUSY, just like the server's /freebusy.ics endpoint
+func formatFreeBusyICal(freeBusy *pb.FreeBusyResponse) string {
+	return string(client.MarshalFreeBusyICal(freeBusy))
+}
+
+func init() {
+	getFreeBusyCmd.Flags().StringVarP(&outFormat, "out", "o", "text", "Configure your output format (text, json, yaml, ics)")
+	getFreeBusyCmd.Flags().StringVar(&fromTime, "from", "", "Start of the timeline (RFC3339, defaults to the start of the cached window)")
+	getFreeBusyCmd.Flags().StringVar(&toTime, "to", "", "End of the timeline (RFC3339, defaults to the end of the cached window)")
+
+	getCmd.AddCommand(getFreeBusyCmd)
+}
@@ -2,12 +2,14 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/sierrasoftworks/humane-errors-go"
 	"github.com/spechtlabs/go-otel-utils/otelprovider"
 	"github.com/spechtlabs/go-otel-utils/otelzap"
 	"github.com/spf13/cobra"
@@ -68,6 +70,29 @@ func init() {
 	if err != nil {
 		panic(fmt.Errorf("fatal binding flag: %w", err))
 	}
+
+	rootCmd.PersistentFlags().String("api-key", "", "API key to present to the Server (or set CALAPI_API_KEY)")
+	err = viper.BindPFlag("client.apiKey", rootCmd.PersistentFlags().Lookup("api-key"))
+	if err != nil {
+		panic(fmt.Errorf("fatal binding flag: %w", err))
+	}
+
+	err = viper.BindEnv("client.apiKey", "CALAPI_API_KEY")
+	if err != nil {
+		panic(fmt.Errorf("fatal binding env: %w", err))
+	}
+
+	// serve the last known good events of a failing calendar for up to one day
+	viper.SetDefault("server.maxStaleness", 24*time.Hour)
+
+	// keep streams alive through proxies that close idle connections
+	viper.SetDefault("server.heartbeat", 30*time.Second)
+
+	// announce events to streaming clients five minutes before they start
+	viper.SetDefault("server.startingSoon", 5*time.Minute)
+
+	// private events only tell that the room is taken
+	viper.SetDefault("server.privacy.title", "Busy")
 }
 
 func initConfig() {
@@ -88,10 +113,15 @@ func initConfig() {
 	viper.SetEnvPrefix("CALAPI")
 	viper.AutomaticEnv()
 
-	// Find and read the config file
+	// Find and read the config file. Client commands work without one, 'serve' checks for it itself.
 	if err := viper.ReadInConfig(); err != nil {
-		// Handle errors reading the config file
-		panic(fmt.Errorf("fatal error config file: %w", err))
+		var notFound viper.ConfigFileNotFoundError
+		if configFileName != "" || !errors.As(err, &notFound) {
+			humane.Eprint(humane.Wrap(err, "unable to read config file",
+				"make sure the file passed with --config exists and is valid YAML",
+			))
+			os.Exit(1)
+		}
 	}
 
 	hostname = viper.GetString("server.host")
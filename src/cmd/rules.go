@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/SpechtLabs/CalendarAPI/pkg/client"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/table"
+	"github.com/spechtlabs/go-otel-utils/otelzap"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	rulesCalendar string
+	rulesFrom     string
+	rulesTo       string
+)
+
+var rulesCmd = &cobra.Command{
+	Use:     "rules",
+	Example: "calendarapi rules",
+	Run: func(cmd *cobra.Command, args []string) {
+	},
+}
+
+var testRulesCmd = &cobra.Command{
+	Use:     "test [ics file]",
+	Example: "calendarapi rules test -c config.yaml -q work --from 2025-04-01 --to 2025-04-08 work.ics",
+	Long:    "Run the events of a local iCal file through the configured rules and show which rule matched each event",
+	Args:    cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		// dates start at midnight in the calendar's time zone, just like its window
+		location := client.ConfiguredCalendar(rulesCalendar).Location()
+
+		from, err := parseWindowTime(rulesFrom, location)
+		if err != nil {
+			otelzap.L().Fatal(fmt.Sprintf("Invalid --from time: %v", err))
+		}
+
+		to, err := parseWindowTime(rulesTo, location)
+		if err != nil {
+			otelzap.L().Fatal(fmt.Sprintf("Invalid --to time: %v", err))
+		}
+
+		ical, err := os.Open(args[0])
+		if err != nil {
+			otelzap.L().Fatal(fmt.Sprintf("Failed to open iCal file: %v", err))
+		}
+		defer func(ical *os.File) {
+			if err := ical.Close(); err != nil {
+				otelzap.L().Sugar().Errorw("failed to close iCal file", zap.Error(err))
+			}
+		}(ical)
+
+		traces, herr := client.TraceRules(ical, rulesCalendar, from, to)
+		if herr != nil {
+			otelzap.L().Fatal(herr.Display())
+		}
+
+		switch outFormat {
+		case "json":
+			json, err := json.Marshal(traces)
+			if err != nil {
+				otelzap.L().Sugar().Error("failed to parse rule results", zap.Error(err))
+			}
+			fmt.Println(string(json))
+
+		case "yaml":
+			yaml, err := yaml.Marshal(traces)
+			if err != nil {
+				otelzap.L().Sugar().Error("failed to parse rule results", zap.Error(err))
+			}
+			fmt.Println(string(yaml))
+
+		default:
+			fmt.Println(formatRuleTraces(traces))
+		}
+	},
+}
+
+// parseWindowTime parses a date (2006-01-02) in the location or a RFC3339 time. An empty value
+// yields the zero time.
+func parseWindowTime(value string, location *time.Location) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	t, err := time.ParseInLocation(time.DateOnly, value, location)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("'%s' is neither a date (2006-01-02) nor a RFC3339 time", value)
+	}
+
+	return t, nil
+}
+
+func formatRuleTraces(traces []client.RuleTrace) string {
+	// Styles
+	headerStyle := lipgloss.NewStyle().Bold(true).Padding(0, 1)
+	cellStyle := lipgloss.NewStyle().Padding(0, 1)
+	skippedStyle := cellStyle.Foreground(lipgloss.Color("#999999")).Strikethrough(true)
+
+	t := table.New().
+		Border(lipgloss.NormalBorder()).
+		Headers("Start", "Event", "Rule", "Result", "Title", "Message", "Busy", "Important", "Private")
+
+	for _, trace := range traces {
+		if trace.Skipped {
+			t.Row(formatUnix(trace.Start), trace.Title, trace.Rule, fmt.Sprintf("skipped (%s)", trace.Reason), "", "", "", "", "")
+			continue
+		}
+
+		t.Row(formatUnix(trace.Start), trace.Title, trace.Rule, "included",
+			trace.Event.Title, trace.Event.Message, trace.Event.Busy.String(),
+			strconv.FormatBool(trace.Event.Important), strconv.FormatBool(trace.Event.Private))
+	}
+
+	t.StyleFunc(func(row, col int) lipgloss.Style {
+		switch {
+		case row == table.HeaderRow:
+			return headerStyle
+		case traces[row].Skipped:
+			return skippedStyle
+		default:
+			return cellStyle
+		}
+	})
+
+	return t.Render()
+}
+
+func init() {
+	testRulesCmd.Flags().StringVarP(&rulesCalendar, "calendar", "q", "", "Name of the calendar the events belong to")
+	testRulesCmd.Flags().StringVar(&rulesFrom, "from", "", "Only test events after this date or time (2006-01-02 or RFC3339), defaults to the calendar's window")
+	testRulesCmd.Flags().StringVar(&rulesTo, "to", "", "Only test events before this date or time (2006-01-02 or RFC3339), defaults to the calendar's window")
+	testRulesCmd.Flags().StringVarP(&outFormat, "out", "o", "text", "Configure your output format (text, json, yaml)")
+
+	rulesCmd.AddCommand(testRulesCmd)
+	rootCmd.AddCommand(rulesCmd)
+}
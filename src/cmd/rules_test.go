@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseWindowTime(t *testing.T) {
+	loc := time.FixedZone("CEST", 2*60*60)
+
+	tests := []struct {
+		value string
+		want  time.Time
+	}{
+		{value: "", want: time.Time{}},
+		{value: "2025-04-01", want: time.Date(2025, time.April, 1, 0, 0, 0, 0, loc)},
+		{value: "2025-04-01T12:00:00Z", want: time.Date(2025, time.April, 1, 12, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		got, err := parseWindowTime(tt.value, loc)
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v", tt.value, err)
+		}
+
+		if !got.Equal(tt.want) {
+			t.Errorf("%q: got %s, want %s", tt.value, got, tt.want)
+		}
+	}
+
+	if _, err := parseWindowTime("tomorrow", loc); err == nil {
+		t.Error("expected an error for an invalid time")
+	}
+}
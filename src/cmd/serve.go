@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"os"
@@ -47,9 +48,41 @@ func initCalendarRefresh(iCalClient *client.ICalClient) chan struct{} {
 	return quitRefreshTicker
 }
 
-func viperConfigChange(iCalClient *client.ICalClient, quitRefreshTicker *chan struct{}) {
-	viper.OnConfigChange(func(e fsnotify.Event) {
+// watchConfig reloads the config file whenever it changes. Changed files are validated first,
+// invalid ones are rejected and the previous configuration is kept.
+func watchConfig(iCalClient *client.ICalClient, quitRefreshTicker *chan struct{}) {
+	configFile := viper.ConfigFileUsed()
+
+	// watch using a separate instance, the active configuration must only change once the new one is validated
+	watcher := viper.New()
+	watcher.SetConfigFile(configFile)
+	watcher.OnConfigChange(func(e fsnotify.Event) {
 		otelzap.L().Sugar().Infow("Config file change detected. Reloading.", "filename", e.Name)
+
+		raw, candidate, err := readConfigCandidate(configFile)
+		if err != nil {
+			otelzap.L().WithError(err).Error("Unable to read the changed config file. Keeping the previous configuration.")
+			return
+		}
+
+		if !logConfigIssues(client.ValidateConfig(candidate)) {
+			otelzap.L().Error("The changed config file is invalid. Keeping the previous configuration.")
+			return
+		}
+
+		keys, herr := api.ResolveAPIKeys(candidate)
+		if herr != nil {
+			otelzap.L().WithError(herr).Error("Unable to resolve the API keys of the changed config file. Keeping the previous configuration.")
+			return
+		}
+
+		if err := viper.ReadConfig(bytes.NewReader(raw)); err != nil {
+			otelzap.L().WithError(err).Error("Unable to apply the changed config file. Keeping the previous configuration.")
+			return
+		}
+
+		api.SetAPIKeys(keys)
+
 		iCalClient.FetchEvents(context.Background())
 
 		// Refresh calendar watch timer
@@ -69,6 +102,7 @@ func viperConfigChange(iCalClient *client.ICalClient, quitRefreshTicker *chan st
 			)
 		}
 	})
+	watcher.WatchConfig()
 }
 
 var serveCmd = &cobra.Command{
@@ -77,6 +111,20 @@ var serveCmd = &cobra.Command{
 	Example: "meetingepd version",
 	Args:    cobra.ExactArgs(0),
 	Run: func(cmd *cobra.Command, args []string) {
+		if viper.ConfigFileUsed() == "" {
+			otelzap.L().Fatal("No config file found, pass one with --config")
+		}
+
+		if !logConfigIssues(client.ValidateConfig(viper.GetViper())) {
+			otelzap.L().Fatal("Invalid configuration, run 'calendarapi config validate' for details")
+		}
+
+		keys, herr := api.ResolveAPIKeys(viper.GetViper())
+		if herr != nil {
+			otelzap.L().WithError(herr).Fatal("Unable to resolve the API keys")
+		}
+		api.SetAPIKeys(keys)
+
 		if debug {
 			file, err := os.ReadFile(viper.GetViper().ConfigFileUsed())
 			if err != nil {
@@ -85,11 +133,16 @@ var serveCmd = &cobra.Command{
 			otelzap.L().Sugar().With("config_file", string(file)).Debug("Config file used")
 		}
 
-		iCalClient := client.NewICalClient()
+		// load the custom statuses set before the last restart
+		statusStore, err := client.NewStatusStore()
+		if err != nil {
+			otelzap.L().WithError(err).Fatal("Unable to open status store")
+		}
+
+		iCalClient := client.NewICalClient(statusStore)
 
 		quitRefreshTicker := initCalendarRefresh(iCalClient)
-		viperConfigChange(iCalClient, &quitRefreshTicker)
-		viper.WatchConfig()
+		watchConfig(iCalClient, &quitRefreshTicker)
 
 		// Serve Rest-API
 		go func() {
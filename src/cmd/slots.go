@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/SpechtLabs/CalendarAPI/pkg/api"
+	pb "github.com/SpechtLabs/CalendarAPI/pkg/protos"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spechtlabs/go-otel-utils/otelzap"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	slotDuration time.Duration
+	workStart    string
+	workEnd      string
+	workDays     []string
+	slotTimezone string
+	slotLimit    int32
+)
+
+var getSlotsCmd = &cobra.Command{
+	Use:     "slots [calendar_name...]",
+	Example: "meetingepd get slots room-a room-b --duration 1h --start 09:00 --end 17:00 --days Mon,Tue,Wed,Thu,Fri",
+	Long:    "Find slots in which all calendars are free, earliest first",
+	Run: func(cmd *cobra.Command, args []string) {
+		req := &pb.FindSlotsRequest{
+			CalendarNames:     args,
+			Duration:          int64(slotDuration.Seconds()),
+			WorkingHoursStart: workStart,
+			WorkingHoursEnd:   workEnd,
+			WorkingDays:       workDays,
+			Timezone:          slotTimezone,
+			Limit:             slotLimit,
+		}
+
+		if fromTime != "" {
+			from, err := time.Parse(time.RFC3339, fromTime)
+			if err != nil {
+				otelzap.L().Fatal(fmt.Sprintf("Invalid --from time '%s': %v", fromTime, err))
+			}
+			req.From = from.Unix()
+		}
+
+		if toTime != "" {
+			to, err := time.Parse(time.RFC3339, toTime)
+			if err != nil {
+				otelzap.L().Fatal(fmt.Sprintf("Invalid --to time '%s': %v", toTime, err))
+			}
+			req.To = to.Unix()
+		}
+
+		addr := fmt.Sprintf("%s:%d", hostname, grpcPort)
+
+		conn, client := api.NewGrpcApiClient(addr)
+		defer func(conn *grpc.ClientConn) {
+			err := conn.Close()
+			if err != nil {
+				otelzap.L().Sugar().Errorw("failed to close gRPC connection", zap.Error(err))
+			}
+		}(conn)
+
+		// Contact the server
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		slots, err := client.FindSlots(ctx, req)
+		if err != nil {
+			otelzap.L().Fatal(fmt.Sprintf("Failed to talk to gRPC API (%s) %v", addr, err))
+		}
+
+		switch outFormat {
+		case "json":
+			json, err := json.Marshal(slots)
+			if err != nil {
+				otelzap.L().Sugar().Error("failed to parse slots", zap.Error(err))
+			}
+			fmt.Println(string(json))
+
+		case "yaml":
+			yaml, err := yaml.Marshal(slots)
+			if err != nil {
+				otelzap.L().Sugar().Error("failed to parse slots", zap.Error(err))
+			}
+			fmt.Println(string(yaml))
+
+		default:
+			fmt.Print(formatSlotsText(slots))
+		}
+	},
+}
+
+func formatSlotsText(slots *pb.FindSlotsResponse) string {
+	// Styles
+	headerStyle := lipgloss.NewStyle().Bold(true).Underline(true)
+	contextStyle := lipgloss.NewStyle().Italic(true).Foreground(lipgloss.Color("#999999"))
+	freeStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#00FF00"))
+
+	loc := displayLocation(slots.Timezone)
+	formatTime := func(unix int64) string {
+		return time.Unix(unix, 0).In(loc).Format("Mon 02 Jan " + time.Kitchen)
+	}
+
+	outStr := fmt.Sprintf("%s\n", headerStyle.Render(strings.Join(slots.CalendarNames, ", ")))
+	outStr += contextStyle.Render(fmt.Sprintf("(%s slots, %s - %s, %s)", time.Duration(slots.Duration)*time.Second, formatTime(slots.From), formatTime(slots.To), slots.Timezone))
+	outStr += "\n"
+
+	if len(slots.Slots) == 0 {
+		outStr += "no free slots\n"
+	}
+
+	for _, slot := range slots.Slots {
+		outStr += freeStyle.Render(fmt.Sprintf("%s - %s", formatTime(slot.Start), time.Unix(slot.End, 0).In(loc).Format(time.Kitchen)))
+		outStr += contextStyle.Render(fmt.Sprintf(" (free until %s)", time.Unix(slot.FreeUntil, 0).In(loc).Format(time.Kitchen)))
+		outStr += "\n"
+	}
+
+	return outStr
+}
+
+func init() {
+	getSlotsCmd.Flags().StringVarP(&outFormat, "out", "o", "text", "Configure your output format (text, json, yaml)")
+	getSlotsCmd.Flags().DurationVar(&slotDuration, "duration", 30*time.Minute, "Length of the slots")
+	getSlotsCmd.Flags().StringVar(&fromTime, "from", "", "Start of the search (RFC3339, defaults to now)")
+	getSlotsCmd.Flags().StringVar(&toTime, "to", "", "End of the search (RFC3339, defaults to the end of the cached window)")
+	getSlotsCmd.Flags().StringVar(&workStart, "start", "", "Start of the working hours (15:04, defaults to midnight)")
+	getSlotsCmd.Flags().StringVar(&workEnd, "end", "", "End of the working hours (15:04, defaults to midnight)")
+	getSlotsCmd.Flags().StringSliceVar(&workDays, "days", nil, "Working days like Mon,Tue (defaults to every day)")
+	getSlotsCmd.Flags().StringVar(&slotTimezone, "timezone", "", "Time zone of the working hours (defaults to the server's)")
+	getSlotsCmd.Flags().Int32Var(&slotLimit, "limit", 10, "Maximum number of slots")
+
+	getCmd.AddCommand(getSlotsCmd)
+}
@@ -0,0 +1,322 @@
+package api
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync/atomic"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sierrasoftworks/humane-errors-go"
+	"github.com/spechtlabs/go-otel-utils/otelzap"
+	"github.com/spf13/viper"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/SpechtLabs/CalendarAPI/pkg/client"
+	pb "github.com/SpechtLabs/CalendarAPI/pkg/protos"
+)
+
+const (
+	// anonymousID names callers that don't present a key
+	anonymousID = "anonymous"
+
+	// privacyTokenID names callers that present one of the 'server.privacy.tokens'
+	privacyTokenID = "privacy-token"
+
+	// keyIDKey is the gin context key of the caller's key ID
+	keyIDKey = "key_id"
+
+	// queryKeyKey is the gin context key of the API key taken from the query of a streaming route
+	queryKeyKey = "query_api_key"
+)
+
+// queryKeyRoutes are the routes that accept the API key as 'api_key' query parameter, since
+// browsers can't send headers with EventSource and WebSocket connections
+var queryKeyRoutes = map[string]bool{
+	"/calendar/stream": true,
+	"/calendar/ws":     true,
+}
+
+// methodScopes are the scopes the gRPC methods require. Methods that aren't listed are denied.
+var methodScopes = map[string]string{
+	pb.CalenderService_GetCalendar_FullMethodName:       client.ScopeCalendarRead,
+	pb.CalenderService_GetCurrentEvent_FullMethodName:   client.ScopeCalendarRead,
+	pb.CalenderService_GetNextEvent_FullMethodName:      client.ScopeCalendarRead,
+	pb.CalenderService_GetAvailability_FullMethodName:   client.ScopeCalendarRead,
+	pb.CalenderService_GetFreeBusy_FullMethodName:       client.ScopeCalendarRead,
+	pb.CalenderService_FindSlots_FullMethodName:         client.ScopeCalendarRead,
+	pb.CalenderService_ListCalendars_FullMethodName:     client.ScopeCalendarRead,
+	pb.CalenderService_WatchCalendar_FullMethodName:     client.ScopeCalendarRead,
+	pb.CalenderService_WatchCurrentEvent_FullMethodName: client.ScopeCalendarRead,
+	pb.CalenderService_GetCustomStatus_FullMethodName:   client.ScopeCalendarRead,
+	pb.CalenderService_SetCustomStatus_FullMethodName:   client.ScopeStatusWrite,
+	pb.CalenderService_ClearCustomStatus_FullMethodName: client.ScopeStatusWrite,
+	pb.CalenderService_RefreshCalendar_FullMethodName:   client.ScopeAdminRefresh,
+}
+
+// the scope checks of the REST routes
+var (
+	requireRead        = requireScope(client.ScopeCalendarRead)
+	requireStatusWrite = requireScope(client.ScopeStatusWrite)
+	requireRefresh     = requireScope(client.ScopeAdminRefresh)
+)
+
+// caller is who makes a request, and what they may do
+type caller struct {
+	id     string
+	scopes []string
+}
+
+type callerKey struct{}
+
+// allowed reports whether the caller was granted the scope
+func (c caller) allowed(scope string) bool {
+	return slices.Contains(c.scopes, scope)
+}
+
+// context returns a context that carries the caller and records its key ID in the current span.
+// Callers granted the calendar:private scope see private events unmasked.
+func (c caller) context(ctx context.Context) context.Context {
+	trace.SpanFromContext(ctx).SetAttributes(attribute.String("auth.key_id", c.id))
+
+	if c.allowed(client.ScopeCalendarPrivate) {
+		ctx = client.WithFullDetails(ctx)
+	}
+
+	return context.WithValue(ctx, callerKey{}, c)
+}
+
+// callerFromContext returns the caller stored by caller.context
+func callerFromContext(ctx context.Context) caller {
+	c, _ := ctx.Value(callerKey{}).(caller)
+	return c
+}
+
+// APIKeys are the 'server.auth.keys' with their secrets resolved
+type APIKeys struct {
+	keys []apiKey
+}
+
+// apiKey is an API key with its secret resolved
+type apiKey struct {
+	id     string
+	value  string
+	scopes []string
+}
+
+// activeKeys are the API keys callers authenticate with. They are replaced as a whole when the
+// configuration is reloaded. Until keys are set, all callers are rejected.
+var activeKeys atomic.Pointer[APIKeys]
+
+// ResolveAPIKeys resolves the secrets of the API keys configured in v. Keys whose secret can't
+// be resolved make the whole configuration unusable, rather than locking out their holders later.
+func ResolveAPIKeys(v *viper.Viper) (*APIKeys, humane.Error) {
+	keys, err := client.UnmarshalAPIKeys(v)
+	if err != nil {
+		return nil, humane.Wrap(err, "unable to parse API keys", "check the 'server.auth' section against the documentation")
+	}
+
+	resolved := &APIKeys{keys: make([]apiKey, 0, len(keys))}
+	for _, key := range keys {
+		value, err := key.Key.Resolve()
+		if err != nil {
+			return nil, humane.Wrap(err, fmt.Sprintf("unable to resolve API key %s", key.ID), "check the key's 'value', 'env' or 'file' setting")
+		}
+
+		if value == "" {
+			return nil, humane.New(fmt.Sprintf("API key %s is empty", key.ID), "set 'key' to the secret callers present")
+		}
+
+		resolved.keys = append(resolved.keys, apiKey{id: key.ID, value: value, scopes: key.Scopes})
+	}
+
+	return resolved, nil
+}
+
+// SetAPIKeys makes the keys the ones callers authenticate with
+func SetAPIKeys(keys *APIKeys) {
+	activeKeys.Store(keys)
+}
+
+// authenticate identifies the caller by the API key or bearer token they present. Without any
+// 'server.auth.keys' authentication is disabled and every caller may use the whole API, except for
+// seeing private events, which still takes a privacy token. Otherwise callers without a key are
+// granted the 'server.auth.anonymousScopes' and unknown keys are rejected.
+func authenticate(apiKey string, authorization string) (caller, humane.Error) {
+	token := apiKey
+	if token == "" {
+		token, _ = strings.CutPrefix(authorization, "Bearer ")
+	}
+
+	active := activeKeys.Load()
+	if active == nil {
+		return caller{}, humane.New("the API keys aren't loaded yet", "try again once the server has started")
+	}
+	keys := active.keys
+
+	// what everyone may do without authentication
+	open := []string{client.ScopeCalendarRead, client.ScopeStatusWrite, client.ScopeAdminRefresh}
+
+	if token != "" {
+		for _, key := range keys {
+			if subtle.ConstantTimeCompare([]byte(token), []byte(key.value)) == 1 {
+				return caller{id: key.id, scopes: key.scopes}, nil
+			}
+		}
+
+		if isPrivacyToken(token) {
+			if len(keys) == 0 {
+				return caller{id: privacyTokenID, scopes: append(open, client.ScopeCalendarPrivate)}, nil
+			}
+
+			return caller{id: privacyTokenID, scopes: []string{client.ScopeCalendarRead, client.ScopeCalendarPrivate}}, nil
+		}
+
+		if len(keys) > 0 {
+			return caller{}, humane.New("unknown API key", "present one of the configured 'server.auth.keys' as 'X-API-Key' or 'Authorization: Bearer' header")
+		}
+	}
+
+	if len(keys) == 0 {
+		return caller{id: anonymousID, scopes: open}, nil
+	}
+
+	return caller{id: anonymousID, scopes: viper.GetStringSlice("server.auth.anonymousScopes")}, nil
+}
+
+// isPrivacyToken reports whether the token is one of the 'server.privacy.tokens', whose holders
+// may see private events unmasked
+func isPrivacyToken(token string) bool {
+	for _, allowed := range viper.GetStringSlice("server.privacy.tokens") {
+		if subtle.ConstantTimeCompare([]byte(token), []byte(allowed)) == 1 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// takeQueryKey removes the 'api_key' query parameter of streaming routes from the request before
+// it is logged or traced, and keeps it for authMiddleware
+func takeQueryKey(ct *gin.Context) {
+	if !queryKeyRoutes[ct.FullPath()] {
+		return
+	}
+
+	query := ct.Request.URL.Query()
+	if key := query.Get("api_key"); key != "" {
+		ct.Set(queryKeyKey, key)
+		query.Del("api_key")
+		ct.Request.URL.RawQuery = query.Encode()
+	}
+}
+
+// authMiddleware identifies REST callers, rejecting unknown keys. Keys in headers take precedence
+// over keys passed in the query of streaming routes.
+func authMiddleware(ct *gin.Context) {
+	apiKey := ct.GetHeader("X-API-Key")
+	if apiKey == "" && ct.GetHeader("Authorization") == "" {
+		apiKey = ct.GetString(queryKeyKey)
+	}
+
+	c, err := authenticate(apiKey, ct.GetHeader("Authorization"))
+	if err != nil {
+		_ = ct.AbortWithError(http.StatusUnauthorized, err)
+		return
+	}
+
+	ct.Set(keyIDKey, c.id)
+	ct.Request = ct.Request.WithContext(c.context(ct.Request.Context()))
+	ct.Next()
+}
+
+// requireScope rejects REST callers that weren't granted the scope
+func requireScope(scope string) gin.HandlerFunc {
+	return func(ct *gin.Context) {
+		if c := callerFromContext(ct.Request.Context()); !c.allowed(scope) {
+			_ = ct.AbortWithError(http.StatusForbidden, humane.New(fmt.Sprintf("%s lacks the scope %s", c.id, scope), "use an API key that was granted the scope"))
+			return
+		}
+
+		ct.Next()
+	}
+}
+
+// authorizeCall identifies gRPC callers and checks they were granted the scope the method requires
+func authorizeCall(ctx context.Context, method string) (context.Context, error) {
+	var apiKey, authorization string
+	if values := metadata.ValueFromIncomingContext(ctx, "x-api-key"); len(values) > 0 {
+		apiKey = values[0]
+	}
+	if values := metadata.ValueFromIncomingContext(ctx, "authorization"); len(values) > 0 {
+		authorization = values[0]
+	}
+
+	c, err := authenticate(apiKey, authorization)
+	if err != nil {
+		otelzap.L().Ctx(ctx).Warn("Rejected gRPC call", zap.String("method", method), zap.String("error", err.Display()))
+		return ctx, status.Error(codes.Unauthenticated, err.Display())
+	}
+
+	ctx = c.context(ctx)
+
+	scope, ok := methodScopes[method]
+	if !ok || !c.allowed(scope) {
+		otelzap.L().Ctx(ctx).Warn("Denied gRPC call", zap.String("method", method), zap.String(keyIDKey, c.id), zap.String("scope", scope))
+		return ctx, status.Error(codes.PermissionDenied, fmt.Sprintf("%s lacks the scope %s", c.id, scope))
+	}
+
+	otelzap.L().Ctx(ctx).Debug("gRPC call", zap.String("method", method), zap.String(keyIDKey, c.id))
+	return ctx, nil
+}
+
+// authUnaryInterceptor rejects gRPC callers that aren't allowed to call the method
+func authUnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	ctx, err := authorizeCall(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+// authStreamInterceptor rejects gRPC callers that aren't allowed to call the method
+func authStreamInterceptor(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := authorizeCall(stream.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+
+	return handler(srv, &contextStream{ServerStream: stream, ctx: ctx})
+}
+
+// contextStream replaces the context of a server stream
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
+
+// apiKeyCredentials sends the API key with every gRPC call
+type apiKeyCredentials string
+
+func (k apiKeyCredentials) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return map[string]string{"x-api-key": string(k)}, nil
+}
+
+// RequireTransportSecurity allows the key to be sent without TLS, like everything else the API serves
+func (k apiKeyCredentials) RequireTransportSecurity() bool {
+	return false
+}
@@ -0,0 +1,212 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/SpechtLabs/CalendarAPI/pkg/protos"
+)
+
+// useTestKeys resolves and activates the API keys of the tests
+func useTestKeys(t *testing.T, keys []map[string]any) {
+	t.Helper()
+
+	v := viper.New()
+	v.Set("server.auth.keys", keys)
+
+	resolved, err := ResolveAPIKeys(v)
+	if err != nil {
+		t.Fatalf("unable to resolve the API keys: %v", err.Display())
+	}
+
+	SetAPIKeys(resolved)
+	t.Cleanup(func() { SetAPIKeys(nil) })
+}
+
+func TestAuthorization(t *testing.T) {
+	t.Cleanup(viper.Reset)
+	viper.Set("server.auth.anonymousScopes", []string{"calendar:read"})
+	viper.Set("server.privacy.tokens", []string{"privacy-token"})
+
+	t.Setenv("TEST_WRITER_KEY", "writer-key")
+	useTestKeys(t, []map[string]any{
+		{"id": "reader", "key": "reader-key", "scopes": []string{"calendar:read"}},
+		{"id": "writer", "key": map[string]any{"env": "TEST_WRITER_KEY"}, "scopes": []string{"calendar:read", "status:write"}},
+		{"id": "admin", "key": "admin-key", "scopes": []string{"admin:refresh"}},
+	})
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(authMiddleware)
+	ok := func(ct *gin.Context) { ct.Status(http.StatusOK) }
+	router.GET("/calendar", requireRead, ok)
+	router.POST("/status", requireStatusWrite, ok)
+	router.PUT("/calendar", requireRefresh, ok)
+
+	type outcome struct {
+		rest int
+		grpc codes.Code
+	}
+
+	allowed := outcome{http.StatusOK, codes.OK}
+	denied := outcome{http.StatusForbidden, codes.PermissionDenied}
+	rejected := outcome{http.StatusUnauthorized, codes.Unauthenticated}
+
+	tests := []struct {
+		name    string
+		header  string // header and metadata key the credential is sent as
+		value   string
+		read    outcome
+		write   outcome
+		refresh outcome
+	}{
+		{name: "anonymous", read: allowed, write: denied, refresh: denied},
+		{name: "reader", header: "X-API-Key", value: "reader-key", read: allowed, write: denied, refresh: denied},
+		{name: "writer", header: "X-API-Key", value: "writer-key", read: allowed, write: allowed, refresh: denied},
+		{name: "writer as bearer", header: "Authorization", value: "Bearer writer-key", read: allowed, write: allowed, refresh: denied},
+		{name: "admin", header: "X-API-Key", value: "admin-key", read: denied, write: denied, refresh: allowed},
+		{name: "privacy token", header: "Authorization", value: "Bearer privacy-token", read: allowed, write: denied, refresh: denied},
+		{name: "unknown key", header: "X-API-Key", value: "guessed-key", read: rejected, write: rejected, refresh: rejected},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, call := range []struct {
+				method string
+				path   string
+				rpc    string
+				want   outcome
+			}{
+				{http.MethodGet, "/calendar", pb.CalenderService_GetCalendar_FullMethodName, tt.read},
+				{http.MethodGet, "/calendar", pb.CalenderService_WatchCalendar_FullMethodName, tt.read},
+				{http.MethodPost, "/status", pb.CalenderService_SetCustomStatus_FullMethodName, tt.write},
+				{http.MethodPut, "/calendar", pb.CalenderService_RefreshCalendar_FullMethodName, tt.refresh},
+			} {
+				req := httptest.NewRequest(call.method, call.path, nil)
+				if tt.header != "" {
+					req.Header.Set(tt.header, tt.value)
+				}
+
+				rec := httptest.NewRecorder()
+				router.ServeHTTP(rec, req)
+				if rec.Code != call.want.rest {
+					t.Errorf("%s %s answered %d, want %d", call.method, call.path, rec.Code, call.want.rest)
+				}
+
+				ctx := context.Background()
+				if tt.header != "" {
+					ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(tt.header, tt.value))
+				}
+
+				_, err := authorizeCall(ctx, call.rpc)
+				if code := status.Code(err); code != call.want.grpc {
+					t.Errorf("%s answered %s, want %s", call.rpc, code, call.want.grpc)
+				}
+			}
+		})
+	}
+
+	// methods without a scope are denied to everyone
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-api-key", "admin-key"))
+	if _, err := authorizeCall(ctx, "/calendarapi.CalenderService/Unknown"); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("unknown method answered %s, want %s", status.Code(err), codes.PermissionDenied)
+	}
+}
+
+func TestQueryKey(t *testing.T) {
+	t.Cleanup(viper.Reset)
+	useTestKeys(t, []map[string]any{{"id": "reader", "key": "reader-key", "scopes": []string{"calendar:read"}}})
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(takeQueryKey, authMiddleware)
+
+	var query string
+	ok := func(ct *gin.Context) {
+		query = ct.Request.URL.RawQuery
+		ct.Status(http.StatusOK)
+	}
+	router.GET("/calendar", requireRead, ok)
+	router.GET("/calendar/stream", requireRead, ok)
+	router.GET("/calendar/ws", requireRead, ok)
+
+	tests := []struct {
+		path  string
+		want  int
+		query string // query the handler sees
+	}{
+		{path: "/calendar/stream?calendar=work&api_key=reader-key", want: http.StatusOK, query: "calendar=work"},
+		{path: "/calendar/ws?api_key=reader-key", want: http.StatusOK, query: ""},
+		{path: "/calendar/stream?api_key=guessed-key", want: http.StatusUnauthorized},
+		{path: "/calendar?api_key=reader-key", want: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		query = ""
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+		if rec.Code != tt.want {
+			t.Errorf("GET %s answered %d, want %d", tt.path, rec.Code, tt.want)
+		}
+
+		if query != tt.query {
+			t.Errorf("GET %s was handled with query %q, want %q", tt.path, query, tt.query)
+		}
+	}
+}
+
+func TestAuthorizationWithoutKeys(t *testing.T) {
+	t.Cleanup(viper.Reset)
+	viper.Set("server.auth.anonymousScopes", []string{"calendar:read"})
+
+	// until the keys are loaded, everyone is rejected
+	SetAPIKeys(nil)
+	if _, err := authorizeCall(context.Background(), pb.CalenderService_GetCalendar_FullMethodName); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("call before loading the keys answered %s, want %s", status.Code(err), codes.Unauthenticated)
+	}
+
+	// without any keys, everyone may do everything
+	useTestKeys(t, nil)
+	for _, method := range []string{
+		pb.CalenderService_GetCalendar_FullMethodName,
+		pb.CalenderService_SetCustomStatus_FullMethodName,
+		pb.CalenderService_RefreshCalendar_FullMethodName,
+	} {
+		if _, err := authorizeCall(context.Background(), method); err != nil {
+			t.Errorf("%s answered %s, want OK", method, status.Code(err))
+		}
+	}
+}
+
+func TestResolveAPIKeys(t *testing.T) {
+	tests := []struct {
+		name string
+		key  any
+	}{
+		{name: "unset environment variable", key: map[string]any{"env": "TEST_UNSET_API_KEY"}},
+		{name: "missing file", key: map[string]any{"file": "/nonexistent/api-key"}},
+		{name: "empty key", key: map[string]any{"env": "TEST_EMPTY_API_KEY"}},
+	}
+
+	t.Setenv("TEST_EMPTY_API_KEY", "")
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := viper.New()
+			v.Set("server.auth.keys", []map[string]any{{"id": "broken", "key": tt.key, "scopes": []string{"calendar:read"}}})
+
+			if _, err := ResolveAPIKeys(v); err == nil {
+				t.Error("expected the key to be rejected")
+			}
+		})
+	}
+}
@@ -4,12 +4,15 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"time"
 
 	"github.com/spechtlabs/go-otel-utils/otelzap"
 	"github.com/spf13/viper"
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 
 	"github.com/SpechtLabs/CalendarAPI/pkg/client"
 	pb "github.com/SpechtLabs/CalendarAPI/pkg/protos"
@@ -27,6 +30,8 @@ func NewGrpcApiServer(client *client.ICalClient) *GrpcApi {
 	// Create a server with the OpenTelemetry interceptor
 	srv := grpc.NewServer(
 		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.UnaryInterceptor(authUnaryInterceptor),
+		grpc.StreamInterceptor(authStreamInterceptor),
 	)
 
 	e := &GrpcApi{
@@ -49,11 +54,16 @@ func NewGrpcApiServer(client *client.ICalClient) *GrpcApi {
 
 func NewGrpcApiClient(addr string) (*grpc.ClientConn, pb.CalenderServiceClient) {
 	// Set up a connection to the server with OpenTelemetry instrumentation
-	conn, err := grpc.NewClient(
-		addr,
+	opts := []grpc.DialOption{
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
 		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
-	)
+	}
+
+	if apiKey := viper.GetString("client.apiKey"); apiKey != "" {
+		opts = append(opts, grpc.WithPerRPCCredentials(apiKeyCredentials(apiKey)))
+	}
+
+	conn, err := grpc.NewClient(addr, opts...)
 
 	if err != nil {
 		otelzap.L().Fatal(fmt.Sprintf("gRPC API: failed to connect: %v", err))
@@ -65,23 +75,13 @@ func NewGrpcApiClient(addr string) (*grpc.ClientConn, pb.CalenderServiceClient)
 }
 
 func (e *GrpcApi) GetCalendar(ctx context.Context, req *pb.CalendarRequest) (*pb.CalendarResponse, error) {
-	events := e.client.GetEvents(ctx)
-
 	if req.CalendarName == "" || req.CalendarName == "*" {
 		req.CalendarName = "all"
 	}
 
-	events.CalendarName = req.CalendarName
-
-	// if a specific calendar is requested, we must filter the entries down to the desired calendars
-	if req.CalendarName != "all" {
-		var responseEvents []*pb.CalendarEntry
-		for _, event := range events.Entries {
-			if event.CalendarName == req.CalendarName {
-				responseEvents = append(responseEvents, event)
-			}
-		}
-		events.Entries = responseEvents
+	events, err := e.client.GetEvents(ctx, req.CalendarName, req.From, req.To)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Display())
 	}
 
 	return events, nil
@@ -97,9 +97,108 @@ func (e *GrpcApi) GetCurrentEvent(ctx context.Context, req *pb.CalendarRequest)
 	return currentEvent, nil
 }
 
-func (e *GrpcApi) RefreshCalendar(ctx context.Context, _ *pb.CalendarRequest) (*pb.RefreshCalendarResponse, error) {
-	e.client.FetchEvents(ctx)
-	return nil, nil
+func (e *GrpcApi) GetNextEvent(ctx context.Context, req *pb.CalendarRequest) (*pb.CalendarEntry, error) {
+	if req.CalendarName == "" || req.CalendarName == "*" {
+		req.CalendarName = "all"
+	}
+
+	return e.client.GetNextEvent(ctx, req.CalendarName), nil
+}
+
+func (e *GrpcApi) GetAvailability(ctx context.Context, req *pb.CalendarRequest) (*pb.Availability, error) {
+	if req.CalendarName == "" || req.CalendarName == "*" {
+		req.CalendarName = "all"
+	}
+
+	return e.client.GetAvailability(ctx, req.CalendarName), nil
+}
+
+func (e *GrpcApi) GetFreeBusy(ctx context.Context, req *pb.FreeBusyRequest) (*pb.FreeBusyResponse, error) {
+	freeBusy, err := e.client.GetFreeBusy(ctx, req.CalendarNames, req.From, req.To)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Display())
+	}
+
+	return freeBusy, nil
+}
+
+func (e *GrpcApi) FindSlots(ctx context.Context, req *pb.FindSlotsRequest) (*pb.FindSlotsResponse, error) {
+	slots, err := e.client.FindSlots(ctx, req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Display())
+	}
+
+	return slots, nil
+}
+
+func (e *GrpcApi) RefreshCalendar(ctx context.Context, req *pb.CalendarRequest) (*pb.RefreshCalendarResponse, error) {
+	if req.CalendarName == "" || req.CalendarName == "*" {
+		req.CalendarName = "all"
+	}
+
+	result, err := e.client.RefreshCalendar(ctx, req.CalendarName)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Display())
+	}
+
+	return result, nil
+}
+
+func (e *GrpcApi) ListCalendars(ctx context.Context, _ *pb.ListCalendarsRequest) (*pb.ListCalendarsResponse, error) {
+	return e.client.ListCalendars(ctx), nil
+}
+
+func (e *GrpcApi) WatchCalendar(req *pb.WatchRequest, stream pb.CalenderService_WatchCalendarServer) error {
+	if req.CalendarName == "" || req.CalendarName == "*" {
+		req.CalendarName = "all"
+	}
+
+	build := func(ctx context.Context) (*pb.WatchCalendarResponse, error) {
+		events, err := e.client.GetEvents(ctx, req.CalendarName, 0, 0)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Display())
+		}
+
+		return &pb.WatchCalendarResponse{
+			Calendar: events,
+			Status:   e.client.GetCustomStatus(ctx, &pb.GetCustomStatusRequest{CalendarName: req.CalendarName}),
+		}, nil
+	}
+
+	send := func(token uint64, update *pb.WatchCalendarResponse) error {
+		update.ResumeToken = token
+		return stream.Send(update)
+	}
+
+	heartbeat := func(token uint64) error {
+		return stream.Send(&pb.WatchCalendarResponse{ResumeToken: token, Heartbeat: true})
+	}
+
+	return watch(stream.Context(), e.client, req.CalendarName, req.ResumeToken, build, send, heartbeat)
+}
+
+func (e *GrpcApi) WatchCurrentEvent(req *pb.WatchRequest, stream pb.CalenderService_WatchCurrentEventServer) error {
+	if req.CalendarName == "" || req.CalendarName == "*" {
+		req.CalendarName = "all"
+	}
+
+	build := func(ctx context.Context) (*pb.WatchCurrentEventResponse, error) {
+		return &pb.WatchCurrentEventResponse{
+			Event:  e.client.GetCurrentEvent(ctx, req.CalendarName),
+			Status: e.client.GetCustomStatus(ctx, &pb.GetCustomStatusRequest{CalendarName: req.CalendarName}),
+		}, nil
+	}
+
+	send := func(token uint64, update *pb.WatchCurrentEventResponse) error {
+		update.ResumeToken = token
+		return stream.Send(update)
+	}
+
+	heartbeat := func(token uint64) error {
+		return stream.Send(&pb.WatchCurrentEventResponse{ResumeToken: token, Heartbeat: true})
+	}
+
+	return watch(stream.Context(), e.client, req.CalendarName, req.ResumeToken, build, send, heartbeat)
 }
 
 func (e *GrpcApi) GetCustomStatus(ctx context.Context, req *pb.GetCustomStatusRequest) (*pb.CustomStatus, error) {
@@ -107,12 +206,27 @@ func (e *GrpcApi) GetCustomStatus(ctx context.Context, req *pb.GetCustomStatusRe
 }
 
 func (e *GrpcApi) SetCustomStatus(ctx context.Context, req *pb.SetCustomStatusRequest) (*pb.CustomStatus, error) {
-	e.client.SetCustomStatus(ctx, req)
-	return e.client.GetCustomStatus(ctx, &pb.GetCustomStatusRequest{CalendarName: req.CalendarName}), nil
+	if err := client.ValidateCustomStatus(req.Status, time.Now()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Display())
+	}
+
+	if err := e.client.SetCustomStatus(ctx, req); err != nil {
+		return nil, status.Error(codes.Internal, err.Display())
+	}
+
+	// Return the status as stored, GetCustomStatus would hide statuses that aren't valid yet
+	if req.Status == nil {
+		return &pb.CustomStatus{}, nil
+	}
+
+	return req.Status, nil
 }
 
 func (e *GrpcApi) ClearCustomStatus(ctx context.Context, req *pb.ClearCustomStatusRequest) (*pb.CustomStatus, error) {
-	e.client.SetCustomStatus(ctx, &pb.SetCustomStatusRequest{CalendarName: req.CalendarName, Status: &pb.CustomStatus{}})
+	if err := e.client.SetCustomStatus(ctx, &pb.SetCustomStatusRequest{CalendarName: req.CalendarName, Status: &pb.CustomStatus{}}); err != nil {
+		return nil, status.Error(codes.Internal, err.Display())
+	}
+
 	return e.client.GetCustomStatus(ctx, &pb.GetCustomStatusRequest{CalendarName: req.CalendarName}), nil
 }
 
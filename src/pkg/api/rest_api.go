@@ -5,11 +5,15 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
 	ginzap "github.com/gin-contrib/zap"
 	"github.com/gin-gonic/gin"
 	ginprometheus "github.com/mcuadros/go-gin-prometheus"
+	"github.com/sierrasoftworks/humane-errors-go"
 	"github.com/spechtlabs/go-otel-utils/otelzap"
 	"github.com/spf13/viper"
 	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
@@ -35,6 +39,9 @@ func NewRestApiServer(client *client.ICalClient) *RestApi {
 	// Setup Gin router
 	router := gin.New(func(e *gin.Engine) {})
 
+	// Keep API keys passed in the query out of logs, traces and metrics
+	router.Use(takeQueryKey)
+
 	// Setup otelgin to expose Open Telemetry
 	router.Use(otelgin.Middleware("conf_room_display"))
 
@@ -54,6 +61,11 @@ func NewRestApiServer(client *client.ICalClient) *RestApi {
 				fields = append(fields, zap.String("trace_id", trace.SpanFromContext(c.Request.Context()).SpanContext().TraceID().String()))
 				fields = append(fields, zap.String("span_id", trace.SpanFromContext(c.Request.Context()).SpanContext().SpanID().String()))
 			}
+
+			// log who made the request
+			if keyID := c.GetString(keyIDKey); keyID != "" {
+				fields = append(fields, zap.String(keyIDKey, keyID))
+			}
 			return fields
 		},
 	}))
@@ -62,12 +74,24 @@ func NewRestApiServer(client *client.ICalClient) *RestApi {
 	p := ginprometheus.NewPrometheus("conf_room_display")
 	p.Use(router)
 
-	router.GET("/calendar", e.GetCalendar)
-	router.GET("/calendar/current", e.GetCurrentEvent)
-	router.PUT("/calendar", e.RefreshCalendar)
-	router.GET("/status", e.GetCustomStatus)
-	router.POST("/status", e.SetCustomStatus)
-	router.DELETE("/status", e.UnsetCustomStatus)
+	// Identify callers by their API key, everything below requires a scope
+	router.Use(authMiddleware)
+
+	router.GET("/calendar", requireRead, e.GetCalendar)
+	router.GET("/calendar.ics", requireRead, e.GetCalendar)
+	router.GET("/calendar/current", requireRead, e.GetCurrentEvent)
+	router.GET("/calendar/next", requireRead, e.GetNextEvent)
+	router.GET("/calendar/availability", requireRead, e.GetAvailability)
+	router.GET("/freebusy", requireRead, e.GetFreeBusy)
+	router.GET("/freebusy.ics", requireRead, e.GetFreeBusy)
+	router.GET("/slots", requireRead, e.FindSlots)
+	router.GET("/calendar/stream", requireRead, e.StreamCalendar)
+	router.GET("/calendar/ws", requireRead, e.WatchCalendar)
+	router.PUT("/calendar", requireRefresh, e.RefreshCalendar)
+	router.GET("/calendars", requireRead, e.ListCalendars)
+	router.GET("/status", requireRead, e.GetCustomStatus)
+	router.POST("/status", requireStatusWrite, e.SetCustomStatus)
+	router.DELETE("/status", requireStatusWrite, e.UnsetCustomStatus)
 
 	// configure the HTTP Server
 	e.srv = &http.Server{
@@ -84,40 +108,235 @@ func (e *RestApi) ListenAndServe() error {
 }
 
 func (e *RestApi) RefreshCalendar(ct *gin.Context) {
-	e.client.FetchEvents(ct.Request.Context())
-}
-
-func (e *RestApi) GetCalendar(ct *gin.Context) {
-	events := e.client.GetEvents(ct.Request.Context())
-
 	queryParams := ct.Request.URL.Query()
-
 	calendar := queryParams.Get("calendar")
 	if calendar == "" || calendar == "*" {
 		calendar = "all"
 	}
 
-	events.CalendarName = calendar
+	result, err := e.client.RefreshCalendar(ct.Request.Context(), calendar)
+	if err != nil {
+		_ = ct.AbortWithError(http.StatusNotFound, err)
+		return
+	}
 
-	// if a specific calendar is requested, we must filter the entries down to the desired calendars
-	if calendar != "all" {
-		var responseEvents []*pb.CalendarEntry
-		for _, event := range events.Entries {
-			if event.CalendarName == calendar {
-				responseEvents = append(responseEvents, event)
-			}
+	// Only report a failure if not a single calendar could be refreshed
+	status := http.StatusBadGateway
+	for _, info := range result.Calendars {
+		if info.LastError == "" {
+			status = http.StatusOK
+			break
 		}
-		events.Entries = responseEvents
+	}
+
+	if len(result.Calendars) == 0 {
+		status = http.StatusOK
 	}
 
 	switch ct.ContentType() {
+	case "application/protobuf":
+		ct.ProtoBuf(status, result)
+	default:
+		ct.JSON(status, result)
+	}
+}
+
+// GetCalendar serves the processed events of the calendars given as comma-separated or repeated
+// 'calendar' query parameter, merged into one calendar. iCal subscribers can fetch them from
+// /calendar.ics, and 'mask=true' masks all events as if they were private.
+func (e *RestApi) GetCalendar(ct *gin.Context) {
+	queryParams := ct.Request.URL.Query()
+	calendars := calendarsParam(queryParams)
+
+	from, err := parseTimeParam(queryParams.Get("from"))
+	if err != nil {
+		_ = ct.AbortWithError(http.StatusBadRequest, fmt.Errorf("invalid 'from' parameter: %w", err))
+		return
+	}
+
+	to, err := parseTimeParam(queryParams.Get("to"))
+	if err != nil {
+		_ = ct.AbortWithError(http.StatusBadRequest, fmt.Errorf("invalid 'to' parameter: %w", err))
+		return
+	}
+
+	ctx := ct.Request.Context()
+	if param := queryParams.Get("mask"); param != "" {
+		mask, err := strconv.ParseBool(param)
+		if err != nil {
+			_ = ct.AbortWithError(http.StatusBadRequest, fmt.Errorf("invalid 'mask' parameter: %w", err))
+			return
+		}
+
+		if mask {
+			ctx = client.WithAllMasked(ctx)
+		}
+	}
+
+	var events *pb.CalendarResponse
+	var herr humane.Error
+	switch {
+	case len(calendars) == 0:
+		events, herr = e.client.GetEvents(ctx, "all", from, to)
+	case len(calendars) == 1 && calendars[0] != "*":
+		events, herr = e.client.GetEvents(ctx, calendars[0], from, to)
+	default:
+		events, herr = e.client.GetMergedEvents(ctx, calendars, from, to)
+	}
+
+	if herr != nil {
+		_ = ct.AbortWithError(http.StatusBadRequest, herr)
+		return
+	}
+
+	contentType := ct.ContentType()
+	if strings.HasSuffix(ct.Request.URL.Path, ".ics") {
+		contentType = "text/calendar"
+	}
+
+	switch contentType {
 	case "application/protobuf":
 		ct.ProtoBuf(http.StatusOK, events)
+	case "text/calendar":
+		ct.Data(http.StatusOK, "text/calendar; charset=utf-8", client.MarshalCalendarICal(events))
 	default:
 		ct.JSON(http.StatusOK, events)
 	}
 }
 
+// GetFreeBusy serves the merged free/busy timeline of the calendars given as comma-separated or
+// repeated 'calendar' query parameter. iCal subscribers can fetch it as VFREEBUSY from /freebusy.ics.
+func (e *RestApi) GetFreeBusy(ct *gin.Context) {
+	queryParams := ct.Request.URL.Query()
+
+	from, err := parseTimeParam(queryParams.Get("from"))
+	if err != nil {
+		_ = ct.AbortWithError(http.StatusBadRequest, fmt.Errorf("invalid 'from' parameter: %w", err))
+		return
+	}
+
+	to, err := parseTimeParam(queryParams.Get("to"))
+	if err != nil {
+		_ = ct.AbortWithError(http.StatusBadRequest, fmt.Errorf("invalid 'to' parameter: %w", err))
+		return
+	}
+
+	freeBusy, herr := e.client.GetFreeBusy(ct.Request.Context(), calendarsParam(queryParams), from, to)
+	if herr != nil {
+		_ = ct.AbortWithError(http.StatusBadRequest, herr)
+		return
+	}
+
+	contentType := ct.ContentType()
+	if strings.HasSuffix(ct.Request.URL.Path, ".ics") {
+		contentType = "text/calendar"
+	}
+
+	switch contentType {
+	case "application/protobuf":
+		ct.ProtoBuf(http.StatusOK, freeBusy)
+	case "text/calendar":
+		ct.Data(http.StatusOK, "text/calendar; charset=utf-8", client.MarshalFreeBusyICal(freeBusy))
+	default:
+		ct.JSON(http.StatusOK, freeBusy)
+	}
+}
+
+// FindSlots serves the free slots of the calendars given as comma-separated or repeated
+// 'calendar' query parameter. 'duration' is a Go duration like 30m, 'start' and 'end' bound the working hours
+// and 'days' (repeated or comma separated) lists the working days.
+func (e *RestApi) FindSlots(ct *gin.Context) {
+	queryParams := ct.Request.URL.Query()
+
+	duration, err := time.ParseDuration(queryParams.Get("duration"))
+	if err != nil {
+		_ = ct.AbortWithError(http.StatusBadRequest, fmt.Errorf("invalid 'duration' parameter: %w", err))
+		return
+	}
+
+	from, err := parseTimeParam(queryParams.Get("from"))
+	if err != nil {
+		_ = ct.AbortWithError(http.StatusBadRequest, fmt.Errorf("invalid 'from' parameter: %w", err))
+		return
+	}
+
+	to, err := parseTimeParam(queryParams.Get("to"))
+	if err != nil {
+		_ = ct.AbortWithError(http.StatusBadRequest, fmt.Errorf("invalid 'to' parameter: %w", err))
+		return
+	}
+
+	var limit int64
+	if param := queryParams.Get("limit"); param != "" {
+		if limit, err = strconv.ParseInt(param, 10, 32); err != nil {
+			_ = ct.AbortWithError(http.StatusBadRequest, fmt.Errorf("invalid 'limit' parameter: %w", err))
+			return
+		}
+	}
+
+	var days []string
+	for _, param := range queryParams["days"] {
+		days = append(days, strings.Split(param, ",")...)
+	}
+
+	slots, herr := e.client.FindSlots(ct.Request.Context(), &pb.FindSlotsRequest{
+		CalendarNames:     calendarsParam(queryParams),
+		Duration:          int64(duration.Seconds()),
+		From:              from,
+		To:                to,
+		WorkingHoursStart: queryParams.Get("start"),
+		WorkingHoursEnd:   queryParams.Get("end"),
+		WorkingDays:       days,
+		Timezone:          queryParams.Get("timezone"),
+		Limit:             int32(limit),
+	})
+	if herr != nil {
+		_ = ct.AbortWithError(http.StatusBadRequest, herr)
+		return
+	}
+
+	switch ct.ContentType() {
+	case "application/protobuf":
+		ct.ProtoBuf(http.StatusOK, slots)
+	default:
+		ct.JSON(http.StatusOK, slots)
+	}
+}
+
+// calendarsParam returns the calendars of the 'calendar' query parameter, which may list them
+// separated by commas or as repeated parameter
+func calendarsParam(queryParams url.Values) []string {
+	var calendars []string
+	for _, param := range queryParams["calendar"] {
+		for _, calendar := range strings.Split(param, ",") {
+			if calendar = strings.TrimSpace(calendar); calendar != "" {
+				calendars = append(calendars, calendar)
+			}
+		}
+	}
+
+	return calendars
+}
+
+// parseTimeParam parses a time query parameter given either as unix timestamp or as RFC3339
+// string. An empty parameter yields 0.
+func parseTimeParam(param string) (int64, error) {
+	if param == "" {
+		return 0, nil
+	}
+
+	if unix, err := strconv.ParseInt(param, 10, 64); err == nil {
+		return unix, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, param)
+	if err != nil {
+		return 0, fmt.Errorf("'%s' is neither a unix timestamp nor a RFC3339 time", param)
+	}
+
+	return t.Unix(), nil
+}
+
 func (e *RestApi) GetCurrentEvent(ct *gin.Context) {
 	queryParams := ct.Request.URL.Query()
 	calendar := queryParams.Get("calendar")
@@ -140,6 +359,56 @@ func (e *RestApi) GetCurrentEvent(ct *gin.Context) {
 	}
 }
 
+func (e *RestApi) GetNextEvent(ct *gin.Context) {
+	queryParams := ct.Request.URL.Query()
+	calendar := queryParams.Get("calendar")
+	if calendar == "" || calendar == "*" {
+		calendar = "all"
+	}
+
+	nextEvent := e.client.GetNextEvent(ct.Request.Context(), calendar)
+
+	status := http.StatusOK
+	if nextEvent == nil {
+		status = http.StatusGone
+	}
+
+	switch ct.ContentType() {
+	case "application/protobuf":
+		ct.ProtoBuf(status, nextEvent)
+	default:
+		ct.JSON(status, nextEvent)
+	}
+}
+
+func (e *RestApi) GetAvailability(ct *gin.Context) {
+	queryParams := ct.Request.URL.Query()
+	calendar := queryParams.Get("calendar")
+	if calendar == "" || calendar == "*" {
+		calendar = "all"
+	}
+
+	availability := e.client.GetAvailability(ct.Request.Context(), calendar)
+
+	switch ct.ContentType() {
+	case "application/protobuf":
+		ct.ProtoBuf(http.StatusOK, availability)
+	default:
+		ct.JSON(http.StatusOK, availability)
+	}
+}
+
+func (e *RestApi) ListCalendars(ct *gin.Context) {
+	calendars := e.client.ListCalendars(ct.Request.Context())
+
+	switch ct.ContentType() {
+	case "application/protobuf":
+		ct.ProtoBuf(http.StatusOK, calendars)
+	default:
+		ct.JSON(http.StatusOK, calendars)
+	}
+}
+
 func (e *RestApi) GetCustomStatus(ct *gin.Context) {
 	queryParams := ct.Request.URL.Query()
 	if !queryParams.Has("calendar") || queryParams.Get("calendar") == "" {
@@ -195,7 +464,14 @@ func (e *RestApi) SetCustomStatus(ct *gin.Context) {
 		}
 	}
 
-	e.client.SetCustomStatus(ct.Request.Context(), &customStatusReq)
+	if err := client.ValidateCustomStatus(customStatusReq.Status, time.Now()); err != nil {
+		_ = ct.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+
+	if err := e.client.SetCustomStatus(ct.Request.Context(), &customStatusReq); err != nil {
+		_ = ct.AbortWithError(http.StatusInternalServerError, err)
+	}
 }
 
 func (e *RestApi) UnsetCustomStatus(ct *gin.Context) {
@@ -229,7 +505,9 @@ func (e *RestApi) UnsetCustomStatus(ct *gin.Context) {
 		}
 	}
 
-	e.client.SetCustomStatus(ct.Request.Context(), &pb.SetCustomStatusRequest{CalendarName: customStatusReq.CalendarName, Status: &pb.CustomStatus{}})
+	if err := e.client.SetCustomStatus(ct.Request.Context(), &pb.SetCustomStatusRequest{CalendarName: customStatusReq.CalendarName, Status: &pb.CustomStatus{}}); err != nil {
+		_ = ct.AbortWithError(http.StatusInternalServerError, err)
+	}
 }
 
 func (e *RestApi) Addr() string {
@@ -0,0 +1,32 @@
+package api
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestCalendarsParam(t *testing.T) {
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{query: "", want: nil},
+		{query: "calendar=work", want: []string{"work"}},
+		{query: "calendar=work,room-a", want: []string{"work", "room-a"}},
+		{query: "calendar=work&calendar=room-a", want: []string{"work", "room-a"}},
+		{query: "calendar=work,%20room-a,&calendar=home", want: []string{"work", "room-a", "home"}},
+		{query: "calendar=", want: nil},
+	}
+
+	for _, tt := range tests {
+		query, err := url.ParseQuery(tt.query)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got := calendarsParam(query); strings.Join(got, "|") != strings.Join(tt.want, "|") {
+			t.Errorf("%q: got %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,250 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spechtlabs/go-otel-utils/otelzap"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+	"golang.org/x/net/websocket"
+
+	"github.com/SpechtLabs/CalendarAPI/pkg/client"
+	pb "github.com/SpechtLabs/CalendarAPI/pkg/protos"
+)
+
+// Types of the events sent to SSE and WebSocket clients
+const (
+	eventCalendarUpdated     = "calendar_updated"      // data: CalendarResponse
+	eventCurrentEventChanged = "current_event_changed" // data: CalendarEntry, null if there is no current event
+	eventStatusChanged       = "status_changed"        // data: CustomStatus
+	eventStartingSoon        = "event_starting_soon"   // data: CalendarEntry
+	eventHeartbeat           = "heartbeat"             // data: null, only sent over WebSockets
+)
+
+// defaultStartingSoon is used if 'server.startingSoon' isn't a positive duration
+const defaultStartingSoon = 5 * time.Minute
+
+// streamEvent is a typed event sent to SSE and WebSocket clients
+type streamEvent struct {
+	Type string `json:"type"`
+	Data any    `json:"data"`
+}
+
+// streamEvents emits the current calendar, current event and custom status right away and then
+// again whenever they change. Events are announced once when they are about to start. If nothing
+// happens for a while, heartbeat is called instead.
+func streamEvents(ctx context.Context, iCalClient *client.ICalClient, calendar string, emit func(event streamEvent) error, heartbeat func() error) error {
+	startingSoon := viper.GetDuration("server.startingSoon")
+	if startingSoon <= 0 {
+		startingSoon = defaultStartingSoon
+	}
+
+	interval := heartbeatInterval()
+	heartbeats := time.NewTicker(interval)
+	defer heartbeats.Stop()
+
+	var lastCalendar, lastCurrent, lastStatus uint64
+	announced := make(map[string]bool)
+
+	for {
+		// fetch the channel before reading the state, so we don't miss changes in between
+		changed := iCalClient.Changed()
+		now := time.Now()
+
+		events, err := iCalClient.GetEvents(ctx, calendar, 0, 0)
+		if err != nil {
+			return err
+		}
+
+		current := iCalClient.GetCurrentEvent(ctx, calendar)
+		status := iCalClient.GetCustomStatus(ctx, &pb.GetCustomStatusRequest{CalendarName: calendar})
+
+		// the tokens leave out the refresh times, so refreshes that didn't change anything aren't pushed
+		pending := make([]streamEvent, 0)
+		if token := resumeToken(events); token != lastCalendar {
+			pending = append(pending, streamEvent{Type: eventCalendarUpdated, Data: events})
+			lastCalendar = token
+		}
+
+		if token := resumeToken(current); token != lastCurrent {
+			pending = append(pending, streamEvent{Type: eventCurrentEventChanged, Data: current})
+			lastCurrent = token
+		}
+
+		if token := resumeToken(status); token != lastStatus {
+			pending = append(pending, streamEvent{Type: eventStatusChanged, Data: status})
+			lastStatus = token
+		}
+
+		// Announce events about to start, and find out when to announce the next one
+		next := iCalClient.NextBoundary(calendar, now)
+		stillSoon := make(map[string]bool)
+		for _, entry := range events.Entries {
+			start := time.Unix(entry.Start, 0)
+			announceAt := start.Add(-startingSoon)
+
+			switch {
+			case !start.After(now):
+				continue
+
+			case announceAt.After(now):
+				if next.IsZero() || announceAt.Before(next) {
+					next = announceAt
+				}
+
+			default:
+				key := fmt.Sprintf("%s|%d|%s", entry.CalendarName, entry.Start, entry.Title)
+				if !announced[key] {
+					pending = append(pending, streamEvent{Type: eventStartingSoon, Data: entry})
+				}
+				stillSoon[key] = true
+			}
+		}
+		announced = stillSoon
+
+		for _, event := range pending {
+			if err := emit(event); err != nil {
+				return err
+			}
+		}
+
+		if len(pending) > 0 {
+			heartbeats.Reset(interval)
+		}
+
+		// a nil channel blocks forever, i.e. nothing to wait for
+		var wakeup <-chan time.Time
+		var timer *time.Timer
+		if !next.IsZero() {
+			timer = time.NewTimer(time.Until(next))
+			wakeup = timer.C
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case <-changed:
+		case <-wakeup:
+
+		case <-heartbeats.C:
+			if err := heartbeat(); err != nil {
+				return err
+			}
+		}
+
+		if timer != nil {
+			timer.Stop()
+		}
+	}
+}
+
+// streamCalendarName returns the calendar requested by a streaming client
+func streamCalendarName(ct *gin.Context) string {
+	calendar := ct.Request.URL.Query().Get("calendar")
+	if calendar == "" || calendar == "*" {
+		return "all"
+	}
+
+	return calendar
+}
+
+// StreamCalendar sends typed events to the client as Server-Sent Events
+func (e *RestApi) StreamCalendar(ct *gin.Context) {
+	calendar := streamCalendarName(ct)
+
+	ct.Header("Cache-Control", "no-cache")
+	ct.Header("Connection", "keep-alive")
+	ct.Header("X-Accel-Buffering", "no") // keep nginx from buffering the stream
+
+	emit := func(event streamEvent) error {
+		// encode ourselves, the SSE renderer doesn't turn nil events into JSON null
+		data, err := json.Marshal(event.Data)
+		if err != nil {
+			return err
+		}
+
+		ct.SSEvent(event.Type, string(data))
+		ct.Writer.Flush()
+		return nil
+	}
+
+	heartbeat := func() error {
+		// comments keep the connection alive without bothering EventSource listeners
+		if _, err := ct.Writer.WriteString(": heartbeat\n\n"); err != nil {
+			return err
+		}
+
+		ct.Writer.Flush()
+		return nil
+	}
+
+	if err := streamEvents(ct.Request.Context(), e.client, calendar, emit, heartbeat); err != nil {
+		otelzap.L().Ctx(ct.Request.Context()).Warn("Event stream closed", zap.String("calendar", calendar), zap.Error(err))
+	}
+}
+
+// checkOrigin rejects WebSocket connections opened by websites other than CalendarAPI itself and
+// the 'server.allowedOrigins'. Displays and scripts usually don't send an Origin header, so
+// connections without one are accepted.
+func checkOrigin(config *websocket.Config, req *http.Request) error {
+	origin, err := websocket.Origin(config, req)
+	if err != nil {
+		return err
+	}
+
+	if origin == nil || strings.EqualFold(origin.Host, req.Host) {
+		return nil
+	}
+
+	for _, allowed := range viper.GetStringSlice("server.allowedOrigins") {
+		if strings.EqualFold(strings.TrimSuffix(allowed, "/"), origin.Scheme+"://"+origin.Host) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("origin %s is not allowed", origin)
+}
+
+// WatchCalendar sends typed events to the client as JSON WebSocket messages
+func (e *RestApi) WatchCalendar(ct *gin.Context) {
+	calendar := streamCalendarName(ct)
+
+	server := websocket.Server{
+		Handshake: checkOrigin,
+
+		Handler: func(ws *websocket.Conn) {
+			ctx, cancel := context.WithCancel(ct.Request.Context())
+			defer cancel()
+
+			// clients aren't expected to send anything, reading only tells us when they go away
+			go func() {
+				defer cancel()
+
+				var msg string
+				for websocket.Message.Receive(ws, &msg) == nil {
+				}
+			}()
+
+			emit := func(event streamEvent) error {
+				return websocket.JSON.Send(ws, event)
+			}
+
+			heartbeat := func() error {
+				return websocket.JSON.Send(ws, streamEvent{Type: eventHeartbeat})
+			}
+
+			if err := streamEvents(ctx, e.client, calendar, emit, heartbeat); err != nil {
+				otelzap.L().Ctx(ctx).Warn("WebSocket closed", zap.String("calendar", calendar), zap.Error(err))
+			}
+		},
+	}
+
+	server.ServeHTTP(ct.Writer, ct.Request)
+}
@@ -0,0 +1,121 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"golang.org/x/net/websocket"
+
+	"github.com/SpechtLabs/CalendarAPI/pkg/client"
+)
+
+func TestStreamEventsSkipsUnchangedRefreshes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "work.ics")
+	writeCalendar := func(title string) {
+		start := time.Now().Add(24 * time.Hour).UTC()
+		ical := strings.Join([]string{
+			"BEGIN:VCALENDAR",
+			"VERSION:2.0",
+			"BEGIN:VEVENT",
+			"UID:stream@example.com",
+			"DTSTAMP:20250101T000000Z",
+			"DTSTART:" + start.Format("20060102T150405Z"),
+			"DTEND:" + start.Add(time.Hour).Format("20060102T150405Z"),
+			"SUMMARY:" + title,
+			"END:VEVENT",
+			"END:VCALENDAR",
+		}, "\r\n")
+
+		if err := os.WriteFile(path, []byte(ical), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Cleanup(viper.Reset)
+	viper.Set("server.lookAhead", 48*time.Hour)
+	viper.Set("calendars", []map[string]any{{"name": "work", "from": "file", "ical": path}})
+	viper.Set("rules", []map[string]any{{"name": "everything", "key": "title", "contains": []string{"*"}}})
+
+	writeCalendar("Standup")
+	iCalClient := client.NewICalClient(client.NewMemoryStatusStore())
+	iCalClient.FetchEvents(context.Background())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events := make(chan streamEvent, 16)
+	emit := func(event streamEvent) error {
+		events <- event
+		return nil
+	}
+
+	go func() {
+		_ = streamEvents(ctx, iCalClient, "all", emit, func() error { return nil })
+	}()
+
+	// expect returns the types of the events emitted within the wait
+	expect := func(wait time.Duration) []string {
+		var types []string
+		timeout := time.After(wait)
+		for {
+			select {
+			case event := <-events:
+				types = append(types, event.Type)
+			case <-timeout:
+				return types
+			}
+		}
+	}
+
+	if got := strings.Join(expect(200*time.Millisecond), ","); got != "calendar_updated,current_event_changed,status_changed" {
+		t.Fatalf("initial events %s", got)
+	}
+
+	// a refresh a second later only changes the refresh times
+	time.Sleep(time.Second)
+	iCalClient.FetchEvents(context.Background())
+	if got := expect(200 * time.Millisecond); len(got) > 0 {
+		t.Errorf("unchanged refresh emitted %v", got)
+	}
+
+	writeCalendar("Retro")
+	iCalClient.FetchEvents(context.Background())
+	if got := strings.Join(expect(200*time.Millisecond), ","); got != "calendar_updated" {
+		t.Errorf("changed refresh emitted %s, want calendar_updated", got)
+	}
+}
+
+func TestCheckOrigin(t *testing.T) {
+	t.Cleanup(viper.Reset)
+	viper.Set("server.allowedOrigins", []string{"https://dashboard.example.com/"})
+
+	tests := []struct {
+		origin string
+		ok     bool
+	}{
+		{origin: "", ok: true},
+		{origin: "http://calendar.example.com:8099", ok: true},
+		{origin: "https://Dashboard.example.com", ok: true},
+		{origin: "http://dashboard.example.com", ok: false},
+		{origin: "https://evil.example.com", ok: false},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "http://calendar.example.com:8099/calendar/ws", nil)
+		if tt.origin != "" {
+			req.Header.Set("Origin", tt.origin)
+		}
+
+		err := checkOrigin(&websocket.Config{Version: websocket.ProtocolVersionHybi13}, req)
+		if (err == nil) != tt.ok {
+			t.Errorf("origin %q: got error %v, want allowed %v", tt.origin, err, tt.ok)
+		}
+	}
+}
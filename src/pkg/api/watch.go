@@ -0,0 +1,124 @@
+package api
+
+import (
+	"context"
+	"hash/fnv"
+	"time"
+
+	"github.com/spf13/viper"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/SpechtLabs/CalendarAPI/pkg/client"
+	pb "github.com/SpechtLabs/CalendarAPI/pkg/protos"
+)
+
+// defaultHeartbeat is used if 'server.heartbeat' isn't a positive duration
+const defaultHeartbeat = 30 * time.Second
+
+// heartbeatInterval returns how often idle streams receive a heartbeat
+func heartbeatInterval() time.Duration {
+	interval := viper.GetDuration("server.heartbeat")
+	if interval <= 0 {
+		return defaultHeartbeat
+	}
+
+	return interval
+}
+
+// resumeToken identifies an update by its content, so it stays valid across server restarts and
+// lets us tell whether a client already has the latest state. The refresh bookkeeping that changes
+// with every refresh is left out, otherwise every refresh would look like an update.
+func resumeToken(update proto.Message) uint64 {
+	update = proto.Clone(update)
+	clearRefreshTimes(update)
+
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(update)
+	if err != nil {
+		return 0
+	}
+
+	hash := fnv.New64a()
+	_, _ = hash.Write(data)
+	return hash.Sum64()
+}
+
+// clearRefreshTimes clears the times and durations of the last refresh in the update
+func clearRefreshTimes(update proto.Message) {
+	var calendar *pb.CalendarResponse
+	switch update := update.(type) {
+	case *pb.CalendarResponse:
+		calendar = update
+	case *pb.WatchCalendarResponse:
+		calendar = update.GetCalendar()
+	}
+
+	if calendar == nil {
+		return
+	}
+
+	calendar.LastUpdated = 0
+	for _, info := range calendar.Calendars {
+		info.LastRefresh, info.LastSuccess, info.Duration = 0, 0, 0
+	}
+}
+
+// watch calls build whenever the cached events or custom status change or an event of the
+// calendar starts or ends, and sends the result if it differs from the last update the client
+// received. If nothing happens for a while, a heartbeat is sent instead.
+func watch[T proto.Message](
+	ctx context.Context,
+	iCalClient *client.ICalClient,
+	calendar string,
+	lastToken uint64,
+	build func(ctx context.Context) (T, error),
+	send func(token uint64, update T) error,
+	heartbeat func(token uint64) error,
+) error {
+	interval := heartbeatInterval()
+	heartbeats := time.NewTicker(interval)
+	defer heartbeats.Stop()
+
+	for {
+		// fetch the channel before building the update, so we don't miss changes in between
+		changed := iCalClient.Changed()
+
+		update, err := build(ctx)
+		if err != nil {
+			return err
+		}
+
+		if token := resumeToken(update); token != lastToken {
+			if err := send(token, update); err != nil {
+				return err
+			}
+
+			lastToken = token
+			heartbeats.Reset(interval)
+		}
+
+		// a nil channel blocks forever, i.e. no boundary to wait for
+		var boundary <-chan time.Time
+		var timer *time.Timer
+		if next := iCalClient.NextBoundary(calendar, time.Now()); !next.IsZero() {
+			timer = time.NewTimer(time.Until(next))
+			boundary = timer.C
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case <-changed:
+		case <-boundary:
+
+		case <-heartbeats.C:
+			if err := heartbeat(lastToken); err != nil {
+				return err
+			}
+		}
+
+		if timer != nil {
+			timer.Stop()
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package api
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/SpechtLabs/CalendarAPI/pkg/protos"
+)
+
+func TestResumeToken(t *testing.T) {
+	calendar := func(refresh int64, title string, stale bool) *pb.CalendarResponse {
+		return &pb.CalendarResponse{
+			LastUpdated:  refresh,
+			CalendarName: "all",
+			From:         1743465600,
+			To:           1743552000,
+			Entries:      []*pb.CalendarEntry{{Title: title, Start: 1743498000, End: 1743501600, CalendarName: "work"}},
+			Calendars: []*pb.CalendarInfo{{
+				Name:        "work",
+				Stale:       stale,
+				LastSuccess: refresh,
+				LastRefresh: refresh,
+				Duration:    refresh % 1000,
+				EventCount:  1,
+			}},
+		}
+	}
+
+	status := &pb.CustomStatus{Title: "Focus"}
+
+	tests := []struct {
+		name string
+		a, b proto.Message
+		same bool
+	}{
+		{
+			name: "refreshed calendar",
+			a:    calendar(1743498000, "Standup", false),
+			b:    calendar(1743498300, "Standup", false),
+			same: true,
+		},
+		{
+			name: "refreshed watch update",
+			a:    &pb.WatchCalendarResponse{Calendar: calendar(1743498000, "Standup", false), Status: status},
+			b:    &pb.WatchCalendarResponse{Calendar: calendar(1743498300, "Standup", false), Status: status},
+			same: true,
+		},
+		{
+			name: "changed entry",
+			a:    calendar(1743498000, "Standup", false),
+			b:    calendar(1743498300, "Retro", false),
+		},
+		{
+			name: "stale calendar",
+			a:    &pb.WatchCalendarResponse{Calendar: calendar(1743498000, "Standup", false)},
+			b:    &pb.WatchCalendarResponse{Calendar: calendar(1743498000, "Standup", true)},
+		},
+		{
+			name: "changed status",
+			a:    &pb.WatchCalendarResponse{Calendar: calendar(1743498000, "Standup", false), Status: status},
+			b:    &pb.WatchCalendarResponse{Calendar: calendar(1743498000, "Standup", false)},
+		},
+		{
+			name: "current event",
+			a:    &pb.WatchCurrentEventResponse{Event: &pb.CalendarEntry{Title: "Standup"}},
+			b:    &pb.WatchCurrentEventResponse{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := resumeToken(tt.a), resumeToken(tt.b)
+			if (a == b) != tt.same {
+				t.Errorf("tokens %d and %d, want them to be the same: %v", a, b, tt.same)
+			}
+		})
+	}
+
+	// the update itself is sent to clients and keeps its refresh times
+	update := calendar(1743498000, "Standup", false)
+	resumeToken(update)
+	if update.LastUpdated == 0 || update.Calendars[0].LastRefresh == 0 {
+		t.Error("resumeToken cleared the refresh times of the update")
+	}
+
+	var noEvent *pb.CalendarEntry
+	if resumeToken(noEvent) != resumeToken(noEvent) {
+		t.Error("the token of a missing event isn't stable")
+	}
+}
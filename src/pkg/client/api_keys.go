@@ -0,0 +1,31 @@
+package client
+
+import (
+	"github.com/spf13/viper"
+)
+
+// Scopes grant the holders of API keys access to parts of the API
+const (
+	ScopeCalendarRead    = "calendar:read"    // events, calendars, free/busy, slots and custom statuses
+	ScopeCalendarPrivate = "calendar:private" // private events unmasked
+	ScopeStatusWrite     = "status:write"     // setting and clearing custom statuses
+	ScopeAdminRefresh    = "admin:refresh"    // refreshing calendars on demand
+)
+
+// Scopes lists all scopes API keys can be granted
+var Scopes = []string{ScopeCalendarRead, ScopeCalendarPrivate, ScopeStatusWrite, ScopeAdminRefresh}
+
+// APIKey grants the callers presenting the key the scopes. The ID names the caller in logs and
+// traces, so the key itself never shows up there.
+type APIKey struct {
+	ID     string   `mapstructure:"id"`
+	Key    Secret   `mapstructure:"key"`
+	Scopes []string `mapstructure:"scopes"`
+}
+
+// UnmarshalAPIKeys returns the API keys configured in 'server.auth.keys'
+func UnmarshalAPIKeys(v *viper.Viper) ([]APIKey, error) {
+	var keys []APIKey
+	err := v.UnmarshalKey("server.auth.keys", &keys, withSecretDecodeHook)
+	return keys, err
+}
@@ -0,0 +1,121 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	"github.com/spechtlabs/go-otel-utils/otelzap"
+
+	pb "github.com/SpechtLabs/CalendarAPI/pkg/protos"
+)
+
+// GetNextEvent returns the first event of the calendar ("all" for every calendar) that hasn't
+// started yet, or nil if there is none in the cached window. Of events starting at the same
+// time, important ones are preferred.
+func (e *ICalClient) GetNextEvent(ctx context.Context, calendar string) *pb.CalendarEntry {
+	ctx, span := e.tracer.Start(ctx, "ICalClient.GetNextEvent")
+	defer span.End()
+
+	if e.cache == nil {
+		otelzap.L().Ctx(ctx).Info("Experiencing cold. Fetching events now!")
+		e.FetchEvents(ctx)
+	}
+
+	e.cacheMux.RLock()
+	defer e.cacheMux.RUnlock()
+
+	// Events only count as current strictly after their start, so events starting right now are still next
+	now := time.Now().Unix()
+	mask := privacyMasker(ctx)
+
+	var next *pb.CalendarEntry
+	for _, entry := range e.cache.Entries {
+		if calendar != "all" && entry.CalendarName != calendar {
+			continue
+		}
+
+		if entry.Start < now {
+			continue
+		}
+
+		// The cache is sorted by start, so we are done once we are past the first start
+		if next != nil && entry.Start > next.Start {
+			break
+		}
+
+		// hidden events don't count, the next visible one is next
+		entry = mask(entry)
+		if entry == nil {
+			continue
+		}
+
+		if next == nil || (entry.Important && !next.Important) {
+			next = entry
+		}
+	}
+
+	return next
+}
+
+// GetAvailability returns whether the calendar ("all" for every calendar) is busy right now and
+// until when. Only events that aren't free block the calendar. Back-to-back and overlapping events
+// are merged, so a calendar is busy until the end of the last of them.
+func (e *ICalClient) GetAvailability(ctx context.Context, calendar string) *pb.Availability {
+	ctx, span := e.tracer.Start(ctx, "ICalClient.GetAvailability")
+	defer span.End()
+
+	if e.cache == nil {
+		otelzap.L().Ctx(ctx).Info("Experiencing cold. Fetching events now!")
+		e.FetchEvents(ctx)
+	}
+
+	e.cacheMux.RLock()
+	defer e.cacheMux.RUnlock()
+
+	availability := &pb.Availability{
+		CalendarName: calendar,
+		Timezone:     e.cache.Timezone,
+	}
+
+	for _, info := range e.cache.Calendars {
+		if info.Name == calendar {
+			availability.Timezone = info.Timezone
+		}
+	}
+
+	now := time.Now().Unix()
+	for _, entry := range e.cache.Entries {
+		if calendar != "all" && entry.CalendarName != calendar {
+			continue
+		}
+
+		if entry.Busy == pb.BusyState_Free || entry.End <= now {
+			continue
+		}
+
+		// The cache is sorted by start, so the first blocking event decides whether we are busy
+		// and every following one can only extend that state
+		switch {
+		case availability.Until == 0 && entry.Start <= now:
+			availability.Busy = true
+			availability.Until = entry.End
+
+		case availability.Until == 0:
+			availability.Until = entry.Start
+
+		case availability.Busy && entry.Start <= availability.Until:
+			availability.Until = max(availability.Until, entry.End)
+		}
+
+		if !availability.Busy && availability.Until != 0 {
+			break
+		}
+	}
+
+	// Busy until the end of the cached window means we don't know when it ends
+	if availability.Busy && availability.Until >= e.cache.To {
+		availability.Until = 0
+	}
+
+	return availability
+}
@@ -0,0 +1,146 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+
+	pb "github.com/SpechtLabs/CalendarAPI/pkg/protos"
+)
+
+func TestGetNextEvent(t *testing.T) {
+	t.Cleanup(viper.Reset)
+	viper.Set("server.privacy.hide", true)
+
+	now := time.Now().Truncate(time.Minute)
+	at := func(minutes int) int64 { return now.Add(time.Duration(minutes) * time.Minute).Unix() }
+
+	tests := []struct {
+		name    string
+		entries []*pb.CalendarEntry
+		want    string // title of the next event, empty if there is none
+	}{
+		{
+			name: "first upcoming",
+			entries: []*pb.CalendarEntry{
+				{Title: "Running", Start: at(-30), End: at(30)},
+				{Title: "Soon", Start: at(60), End: at(90)},
+				{Title: "Later", Start: at(120), End: at(150)},
+			},
+			want: "Soon",
+		},
+		{
+			name: "important one of the same start",
+			entries: []*pb.CalendarEntry{
+				{Title: "Regular", Start: at(60), End: at(90)},
+				{Title: "Important", Start: at(60), End: at(90), Important: true},
+				{Title: "Later and important", Start: at(120), End: at(150), Important: true},
+			},
+			want: "Important",
+		},
+		{
+			name: "hidden events don't count",
+			entries: []*pb.CalendarEntry{
+				{Title: "Doctor", Start: at(60), End: at(90), Private: true},
+				{Title: "Visible", Start: at(120), End: at(150)},
+			},
+			want: "Visible",
+		},
+		{
+			name:    "nothing upcoming",
+			entries: []*pb.CalendarEntry{{Title: "Running", Start: at(-30), End: at(30)}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, entry := range tt.entries {
+				entry.CalendarName = "work"
+			}
+
+			e := NewICalClient(NewMemoryStatusStore())
+			e.cache = &pb.CalendarResponse{From: at(-60), To: at(24 * 60), Entries: tt.entries}
+
+			next := e.GetNextEvent(context.Background(), "work")
+			switch {
+			case tt.want == "" && next != nil:
+				t.Errorf("got %s, want no next event", next.Title)
+			case tt.want != "" && (next == nil || next.Title != tt.want):
+				t.Errorf("got %v, want %s", next, tt.want)
+			}
+
+			if other := e.GetNextEvent(context.Background(), "home"); other != nil {
+				t.Errorf("got %s for a calendar without events", other.Title)
+			}
+		})
+	}
+}
+
+func TestGetAvailability(t *testing.T) {
+	now := time.Now().Truncate(time.Minute)
+	at := func(minutes int) int64 { return now.Add(time.Duration(minutes) * time.Minute).Unix() }
+
+	tests := []struct {
+		name    string
+		entries []*pb.CalendarEntry
+		busy    bool
+		until   int64
+	}{
+		{name: "no events"},
+		{
+			name:    "free until the next event",
+			entries: []*pb.CalendarEntry{{Start: at(60), End: at(90), Busy: pb.BusyState_Busy}},
+			until:   at(60),
+		},
+		{
+			name: "back-to-back and overlapping events are merged",
+			entries: []*pb.CalendarEntry{
+				{Start: at(-30), End: at(30), Busy: pb.BusyState_Busy},
+				{Start: at(0), End: at(20), Busy: pb.BusyState_Tentative},
+				{Start: at(30), End: at(60), Busy: pb.BusyState_OutOfOffice},
+				{Start: at(90), End: at(120), Busy: pb.BusyState_Busy},
+			},
+			busy:  true,
+			until: at(60),
+		},
+		{
+			name: "free events don't block",
+			entries: []*pb.CalendarEntry{
+				{Start: at(-30), End: at(30), Busy: pb.BusyState_Free},
+				{Start: at(45), End: at(60), Busy: pb.BusyState_WorkingElsewhere},
+			},
+			until: at(45),
+		},
+		{
+			name: "ended events don't block",
+			entries: []*pb.CalendarEntry{
+				{Start: at(-60), End: at(0), Busy: pb.BusyState_Busy},
+				{Start: at(30), End: at(60), Busy: pb.BusyState_Busy},
+			},
+			until: at(30),
+		},
+		{
+			name:    "busy beyond the cached window",
+			entries: []*pb.CalendarEntry{{Start: at(-30), End: at(24 * 60), Busy: pb.BusyState_Busy}},
+			busy:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, entry := range tt.entries {
+				entry.CalendarName = "work"
+			}
+
+			e := NewICalClient(NewMemoryStatusStore())
+			e.cache = &pb.CalendarResponse{From: at(-60), To: at(24 * 60), Entries: tt.entries}
+
+			availability := e.GetAvailability(context.Background(), "work")
+			if availability.Busy != tt.busy || availability.Until != tt.until {
+				t.Errorf("busy %v until %d, want %v until %d", availability.Busy, availability.Until, tt.busy, tt.until)
+			}
+		})
+	}
+}
@@ -0,0 +1,435 @@
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/sierrasoftworks/humane-errors-go"
+	"github.com/spechtlabs/go-otel-utils/otelzap"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.uber.org/zap"
+)
+
+// caldavTimeFormat is the UTC date-time format used by CalDAV time-range filters (RFC 4791, 9.9)
+const caldavTimeFormat = "20060102T150405Z"
+
+// davMultistatus is the subset of a WebDAV multistatus response (RFC 4918, 14.16) we care about
+type davMultistatus struct {
+	XMLName   xml.Name      `xml:"DAV: multistatus"`
+	Responses []davResponse `xml:"DAV: response"`
+}
+
+type davResponse struct {
+	Href     string        `xml:"DAV: href"`
+	Propstat []davPropstat `xml:"DAV: propstat"`
+}
+
+type davPropstat struct {
+	Prop   davProp `xml:"DAV: prop"`
+	Status string  `xml:"DAV: status"`
+}
+
+type davProp struct {
+	ResourceType         davResourceType `xml:"DAV: resourcetype"`
+	DisplayName          string          `xml:"DAV: displayname"`
+	CurrentUserPrincipal davHref         `xml:"DAV: current-user-principal"`
+	CalendarHomeSet      davHref         `xml:"urn:ietf:params:xml:ns:caldav calendar-home-set"`
+	ComponentSet         davComponentSet `xml:"urn:ietf:params:xml:ns:caldav supported-calendar-component-set"`
+	CalendarData         string          `xml:"urn:ietf:params:xml:ns:caldav calendar-data"`
+	CTag                 string          `xml:"http://calendarserver.org/ns/ getctag"`
+	ETag                 string          `xml:"DAV: getetag"`
+}
+
+type davResourceType struct {
+	Calendar *struct{} `xml:"urn:ietf:params:xml:ns:caldav calendar"`
+}
+
+type davHref struct {
+	Href string `xml:"DAV: href"`
+}
+
+type davComponentSet struct {
+	Components []struct {
+		Name string `xml:"name,attr"`
+	} `xml:"urn:ietf:params:xml:ns:caldav comp"`
+}
+
+// prop returns the properties of the first successful propstat of the response
+func (r davResponse) prop() (davProp, bool) {
+	for _, propstat := range r.Propstat {
+		if propstat.Status == "" || strings.Contains(propstat.Status, " 200 ") {
+			return propstat.Prop, true
+		}
+	}
+
+	return davProp{}, false
+}
+
+// isEventCalendar reports whether the resource is a calendar collection that can hold VEVENTs.
+// Servers that don't announce their supported components are assumed to support VEVENTs.
+func (p davProp) isEventCalendar() bool {
+	if p.ResourceType.Calendar == nil {
+		return false
+	}
+
+	if len(p.ComponentSet.Components) == 0 {
+		return true
+	}
+
+	for _, comp := range p.ComponentSet.Components {
+		if strings.EqualFold(comp.Name, "VEVENT") {
+			return true
+		}
+	}
+
+	return false
+}
+
+const davPropfindBody = `<?xml version="1.0" encoding="utf-8" ?>
+<d:propfind xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav">
+  <d:prop>
+    <d:resourcetype/>
+    <d:displayname/>
+    <d:current-user-principal/>
+    <c:calendar-home-set/>
+    <c:supported-calendar-component-set/>
+  </d:prop>
+</d:propfind>`
+
+const caldavTagBody = `<?xml version="1.0" encoding="utf-8" ?>
+<d:propfind xmlns:d="DAV:" xmlns:cs="http://calendarserver.org/ns/">
+  <d:prop>
+    <cs:getctag/>
+    <d:getetag/>
+  </d:prop>
+</d:propfind>`
+
+const caldavQueryBody = `<?xml version="1.0" encoding="utf-8" ?>
+<c:calendar-query xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav">
+  <d:prop>
+    <c:calendar-data/>
+  </d:prop>
+  <c:filter>
+    <c:comp-filter name="VCALENDAR">
+      <c:comp-filter name="VEVENT">
+        <c:time-range start="%s" end="%s"/>
+      </c:comp-filter>
+    </c:comp-filter>
+  </c:filter>
+</c:calendar-query>`
+
+// getIcalFromCalDAV discovers the calendar collection of the CalDAV calendar, queries all VEVENTs
+// overlapping the window and merges them into a single iCal file. As long as the collection's
+// tag doesn't change, the events queried for the same window last time are returned instead.
+func (e *ICalClient) getIcalFromCalDAV(ctx context.Context, cal Calendar, windowStart time.Time, windowEnd time.Time) (*icalData, humane.Error) {
+	ctx, span := e.tracer.Start(ctx, "ICalClient.getIcalFromCalDAV")
+	defer span.End()
+
+	collection, err := e.caldavCollection(ctx, cal)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.String("caldav.collection", collection))
+
+	tag := e.caldavTag(ctx, cal, collection)
+	if state := e.getCalendarState(cal); tag != "" && state != nil && state.ical.etag == tag &&
+		state.windowStart.Equal(windowStart) && state.windowEnd.Equal(windowEnd) {
+		span.SetAttributes(attribute.Bool("caldav.unchanged", true))
+
+		ical := state.ical
+		return &ical, nil
+	}
+
+	span.SetAttributes(attribute.Bool("caldav.unchanged", false))
+
+	body := fmt.Sprintf(caldavQueryBody, windowStart.UTC().Format(caldavTimeFormat), windowEnd.UTC().Format(caldavTimeFormat))
+	multistatus, err := e.davRequest(ctx, cal, "REPORT", collection, "1", body)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		// the collection might have moved, so discover it again next time
+		e.caldavMux.Lock()
+		delete(e.caldavCollections, caldavCacheKey(cal))
+		e.caldavMux.Unlock()
+
+		return nil, humane.Wrap(err, "failed to query events from CalDAV collection")
+	}
+
+	calendarData := make([]string, 0, len(multistatus.Responses))
+	for _, resp := range multistatus.Responses {
+		if prop, ok := resp.prop(); ok && prop.CalendarData != "" {
+			calendarData = append(calendarData, prop.CalendarData)
+		}
+	}
+
+	span.SetAttributes(attribute.Int("caldav.objects", len(calendarData)))
+
+	return &icalData{raw: mergeCalendarData(calendarData), etag: tag}, nil
+}
+
+// caldavTag returns a tag that changes whenever the collection's content changes, based on its
+// getctag or, for servers without one, its ETag. It is empty if the server has neither, in which
+// case the collection has to be queried on every refresh.
+func (e *ICalClient) caldavTag(ctx context.Context, cal Calendar, collection string) string {
+	multistatus, err := e.davRequest(ctx, cal, "PROPFIND", collection, "0", caldavTagBody)
+	if err != nil {
+		otelzap.L().Ctx(ctx).Debug("Unable to query the CalDAV collection's tag",
+			zap.String("calendar", cal.Name),
+			zap.String("collection", collection),
+			zap.Error(err),
+		)
+		return ""
+	}
+
+	var prop davProp
+	if len(multistatus.Responses) > 0 {
+		prop, _ = multistatus.Responses[0].prop()
+	}
+
+	tag := prop.CTag
+	if tag == "" {
+		tag = prop.ETag
+	}
+
+	if tag == "" {
+		return ""
+	}
+
+	// a tag only refers to the collection it was served for
+	return collection + " " + tag
+}
+
+// caldavCollection returns the URL of the calendar collection to query. If the configured URL
+// isn't a calendar collection itself, the collection is discovered through the principal and its
+// calendar home set (RFC 4791, 6.2.1). Discovered collections are remembered per calendar.
+func (e *ICalClient) caldavCollection(ctx context.Context, cal Calendar) (string, humane.Error) {
+	e.caldavMux.Lock()
+	collection, ok := e.caldavCollections[caldavCacheKey(cal)]
+	e.caldavMux.Unlock()
+
+	if ok {
+		return collection, nil
+	}
+
+	ctx, span := e.tracer.Start(ctx, "ICalClient.caldavCollection")
+	defer span.End()
+
+	multistatus, err := e.davRequest(ctx, cal, "PROPFIND", cal.Ical, "0", davPropfindBody)
+	if err != nil {
+		return "", humane.Wrap(err, "failed to discover CalDAV collection", "make sure 'ical' points to a CalDAV server, principal or calendar collection")
+	}
+
+	var root davProp
+	if len(multistatus.Responses) > 0 {
+		root, _ = multistatus.Responses[0].prop()
+	}
+
+	switch {
+	// the configured URL is the collection itself
+	case root.isEventCalendar():
+		collection = cal.Ical
+
+	// the configured URL knows the calendar home set (e.g. it is the principal)
+	case root.CalendarHomeSet.Href != "":
+		collection, err = e.caldavFindCollection(ctx, cal, resolveHref(cal.Ical, root.CalendarHomeSet.Href))
+
+	// the configured URL knows the principal (e.g. it is the server root)
+	case root.CurrentUserPrincipal.Href != "":
+		principal := resolveHref(cal.Ical, root.CurrentUserPrincipal.Href)
+
+		multistatus, err = e.davRequest(ctx, cal, "PROPFIND", principal, "0", davPropfindBody)
+		if err != nil {
+			return "", humane.Wrap(err, "failed to query CalDAV principal")
+		}
+
+		var principalProp davProp
+		if len(multistatus.Responses) > 0 {
+			principalProp, _ = multistatus.Responses[0].prop()
+		}
+
+		if principalProp.CalendarHomeSet.Href == "" {
+			return "", humane.New(fmt.Sprintf("CalDAV principal %s has no calendar home set", principal), "make sure 'ical' points to a CalDAV server")
+		}
+
+		collection, err = e.caldavFindCollection(ctx, cal, resolveHref(principal, principalProp.CalendarHomeSet.Href))
+
+	default:
+		err = humane.New(fmt.Sprintf("%s is neither a CalDAV calendar collection, nor does it announce a principal or calendar home set", cal.Ical),
+			"make sure 'ical' points to a CalDAV server, principal or calendar collection",
+		)
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	otelzap.L().Ctx(ctx).Info("Discovered CalDAV collection", zap.String("calendar", cal.Name), zap.String("collection", collection))
+
+	e.caldavMux.Lock()
+	e.caldavCollections[caldavCacheKey(cal)] = collection
+	e.caldavMux.Unlock()
+
+	return collection, nil
+}
+
+// caldavCacheKey identifies a discovered collection, so that a changed configuration triggers a new discovery
+func caldavCacheKey(cal Calendar) string {
+	return fmt.Sprintf("%s|%s|%s", cal.Name, cal.Ical, cal.Collection)
+}
+
+// caldavFindCollection lists the calendar home set and picks the configured collection (matched by
+// display name or path segment) or, if none is configured, the first collection supporting VEVENTs.
+func (e *ICalClient) caldavFindCollection(ctx context.Context, cal Calendar, homeSet string) (string, humane.Error) {
+	multistatus, err := e.davRequest(ctx, cal, "PROPFIND", homeSet, "1", davPropfindBody)
+	if err != nil {
+		return "", humane.Wrap(err, "failed to list CalDAV calendar home set")
+	}
+
+	for _, resp := range multistatus.Responses {
+		prop, ok := resp.prop()
+		if !ok || !prop.isEventCalendar() {
+			continue
+		}
+
+		segment := path.Base(strings.TrimSuffix(resp.Href, "/"))
+		if cal.Collection == "" || cal.Collection == prop.DisplayName || cal.Collection == segment {
+			return resolveHref(homeSet, resp.Href), nil
+		}
+	}
+
+	if cal.Collection != "" {
+		return "", humane.New(fmt.Sprintf("no CalDAV calendar collection named '%s' found in %s", cal.Collection, homeSet),
+			"check if 'collection' matches the display name or path of an existing calendar",
+		)
+	}
+
+	return "", humane.New(fmt.Sprintf("no CalDAV calendar collection found in %s", homeSet), "make sure the user has at least one calendar")
+}
+
+// davRequest performs an authorized WebDAV request and parses the multistatus response
+func (e *ICalClient) davRequest(ctx context.Context, cal Calendar, method string, target string, depth string, body string) (*davMultistatus, humane.Error) {
+	ctx, span := e.tracer.Start(ctx, "ICalClient.davRequest")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("http.method", method),
+		attribute.String("http.url", target),
+	)
+
+	req, err := http.NewRequestWithContext(ctx, method, target, strings.NewReader(body))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, humane.Wrap(err, fmt.Sprintf("failed creating request for %s", target), "verify if URL is valid and well-formed")
+	}
+
+	if err := authorizeRequest(req, cal); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, humane.Wrap(err, fmt.Sprintf("failed to authorize request for %s", target), "verify the calendar's 'auth' and 'headers' settings")
+	}
+	req.Header.Set("Content-Type", "application/xml; charset=utf-8")
+	req.Header.Set("Depth", depth)
+
+	client, herr := e.calendarHTTPClient(cal)
+	if herr != nil {
+		span.RecordError(herr)
+		span.SetStatus(codes.Error, herr.Error())
+		return nil, humane.Wrap(herr, fmt.Sprintf("failed to configure TLS for %s", target), "verify the calendar's 'tls' settings")
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, humane.Wrap(err, fmt.Sprintf("failed making request to %s", target), "verify if URL exists and is accessible")
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusMultiStatus {
+		statusErr := fmt.Errorf("unexpected HTTP status: %s", resp.Status)
+		span.SetStatus(codes.Error, "received non-207 status code")
+		span.RecordError(statusErr)
+		return nil, humane.Wrap(statusErr, fmt.Sprintf("%s %s did not return a multistatus response", method, target))
+	}
+
+	var multistatus davMultistatus
+	if err := xml.NewDecoder(resp.Body).Decode(&multistatus); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, humane.Wrap(err, "failed to parse WebDAV multistatus response")
+	}
+
+	return &multistatus, nil
+}
+
+// resolveHref resolves a (usually absolute-path) href of a WebDAV response against the request URL
+func resolveHref(base string, href string) string {
+	baseURL, err := url.Parse(base)
+	if err != nil {
+		return href
+	}
+
+	hrefURL, err := url.Parse(strings.TrimSpace(href))
+	if err != nil {
+		return href
+	}
+
+	return baseURL.ResolveReference(hrefURL).String()
+}
+
+// mergeCalendarData merges the calendar objects returned by a CalDAV server into a single
+// VCALENDAR, dropping the per-object VCALENDAR wrappers and their properties.
+func mergeCalendarData(calendarData []string) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("BEGIN:VCALENDAR\r\nVERSION:2.0\r\nPRODID:-//SpechtLabs//CalendarAPI//EN\r\n")
+
+	for _, data := range calendarData {
+		depth := 0
+		scanner := bufio.NewScanner(strings.NewReader(data))
+		scanner.Buffer(make([]byte, 0, 64*1024), len(data)+1)
+		for scanner.Scan() {
+			line := strings.TrimRight(scanner.Text(), "\r")
+
+			switch {
+			case strings.HasPrefix(line, "BEGIN:"):
+				depth++
+				if depth == 1 {
+					continue
+				}
+
+			case strings.HasPrefix(line, "END:"):
+				depth--
+				if depth == 0 {
+					continue
+				}
+
+			// properties of the VCALENDAR itself (VERSION, PRODID, ...) and their folded lines
+			case depth <= 1:
+				continue
+			}
+
+			buf.WriteString(line)
+			buf.WriteString("\r\n")
+		}
+	}
+
+	buf.WriteString("END:VCALENDAR\r\n")
+	return buf.Bytes()
+}
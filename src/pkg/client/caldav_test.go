@@ -0,0 +1,429 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// caldavStandIn is an in-process CalDAV server with a principal, a calendar home set and a few
+// collections. It records the requests it serves.
+type caldavStandIn struct {
+	*httptest.Server
+
+	mux      sync.Mutex
+	ctag     string   // getctag of the work collection, none if empty
+	requests []string // method, path and depth of every request
+	bodies   []string // bodies of the REPORT requests
+}
+
+const multistatusHead = `<?xml version="1.0" encoding="utf-8"?>
+<d:multistatus xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav" xmlns:cs="http://calendarserver.org/ns/">`
+
+const multistatusTail = `</d:multistatus>`
+
+// caldavObject is a calendar object resource as stored on CalDAV servers, with its own VCALENDAR
+func caldavObject(uid string, summary string, start string) string {
+	return strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//Stand-In//CalDAV//EN",
+		"X-WR-CALNAME:a very long calendar name that the server folded into a",
+		"  continuation line",
+		"BEGIN:VTIMEZONE",
+		"TZID:Europe/Berlin",
+		"BEGIN:STANDARD",
+		"DTSTART:19701025T030000",
+		"TZOFFSETFROM:+0200",
+		"TZOFFSETTO:+0100",
+		"RRULE:FREQ=YEARLY;BYMONTH=10;BYDAY=-1SU",
+		"END:STANDARD",
+		"BEGIN:DAYLIGHT",
+		"DTSTART:19700329T020000",
+		"TZOFFSETFROM:+0100",
+		"TZOFFSETTO:+0200",
+		"RRULE:FREQ=YEARLY;BYMONTH=3;BYDAY=-1SU",
+		"END:DAYLIGHT",
+		"END:VTIMEZONE",
+		"BEGIN:VEVENT",
+		"UID:" + uid,
+		"DTSTAMP:20250101T000000Z",
+		"DTSTART;TZID=Europe/Berlin:" + start,
+		"DURATION:PT1H",
+		"SUMMARY:" + summary,
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+}
+
+func newCaldavStandIn(t *testing.T) *caldavStandIn {
+	s := &caldavStandIn{}
+
+	propfind := map[string]string{
+		// the server root only knows the principal, its calendar-home-set propstat is a 404
+		"/": `<d:response><d:href>/</d:href>
+			<d:propstat><d:prop><c:calendar-home-set/></d:prop><d:status>HTTP/1.1 404 Not Found</d:status></d:propstat>
+			<d:propstat><d:prop><d:current-user-principal><d:href>/principals/alice/</d:href></d:current-user-principal></d:prop><d:status>HTTP/1.1 200 OK</d:status></d:propstat>
+		</d:response>`,
+
+		"/principals/alice/": `<d:response><d:href>/principals/alice/</d:href>
+			<d:propstat><d:prop><c:calendar-home-set><d:href>/calendars/alice/</d:href></c:calendar-home-set></d:prop><d:status>HTTP/1.1 200 OK</d:status></d:propstat>
+		</d:response>`,
+
+		"/calendars/alice/": `<d:response><d:href>/calendars/alice/</d:href>
+			<d:propstat><d:prop><d:resourcetype><d:collection/></d:resourcetype></d:prop><d:status>HTTP/1.1 200 OK</d:status></d:propstat>
+		</d:response>
+		<d:response><d:href>/calendars/alice/gone/</d:href>
+			<d:propstat><d:prop><d:resourcetype><d:collection/><c:calendar/></d:resourcetype><d:displayname>Gone</d:displayname></d:prop><d:status>HTTP/1.1 404 Not Found</d:status></d:propstat>
+		</d:response>
+		<d:response><d:href>/calendars/alice/tasks/</d:href>
+			<d:propstat><d:prop><d:resourcetype><d:collection/><c:calendar/></d:resourcetype><d:displayname>Tasks</d:displayname>
+				<c:supported-calendar-component-set><c:comp name="VTODO"/></c:supported-calendar-component-set></d:prop><d:status>HTTP/1.1 200 OK</d:status></d:propstat>
+		</d:response>
+		<d:response><d:href>/calendars/alice/work/</d:href>
+			<d:propstat><d:prop><d:resourcetype><d:collection/><c:calendar/></d:resourcetype><d:displayname>Work</d:displayname>
+				<c:supported-calendar-component-set><c:comp name="VEVENT"/><c:comp name="VTODO"/></c:supported-calendar-component-set></d:prop><d:status>HTTP/1.1 200 OK</d:status></d:propstat>
+		</d:response>
+		<d:response><d:href>/calendars/alice/private/</d:href>
+			<d:propstat><d:prop><d:resourcetype><d:collection/><c:calendar/></d:resourcetype><d:displayname>Private</d:displayname></d:prop><d:status>HTTP/1.1 200 OK</d:status></d:propstat>
+		</d:response>`,
+
+		"/calendars/alice/work/": `<d:response><d:href>/calendars/alice/work/</d:href>
+			<d:propstat><d:prop><d:resourcetype><d:collection/><c:calendar/></d:resourcetype><d:displayname>Work</d:displayname><cs:getctag>{ctag}</cs:getctag></d:prop><d:status>HTTP/1.1 200 OK</d:status></d:propstat>
+		</d:response>`,
+	}
+
+	report := map[string]string{
+		"/calendars/alice/work/": fmt.Sprintf(`<d:response><d:href>/calendars/alice/work/standup.ics</d:href>
+			<d:propstat><d:prop><c:calendar-data>%s</c:calendar-data></d:prop><d:status>HTTP/1.1 200 OK</d:status></d:propstat>
+		</d:response>
+		<d:response><d:href>/calendars/alice/work/deleted.ics</d:href>
+			<d:propstat><d:prop><c:calendar-data/></d:prop><d:status>HTTP/1.1 404 Not Found</d:status></d:propstat>
+		</d:response>
+		<d:response><d:href>/calendars/alice/work/review.ics</d:href>
+			<d:propstat><d:prop><c:calendar-data>%s</c:calendar-data></d:prop><d:status>HTTP/1.1 200 OK</d:status></d:propstat>
+		</d:response>`,
+			caldavObject("standup@stand-in", "Standup", "20250401T090000"),
+			caldavObject("review@stand-in", "Review", "20250401T140000"),
+		),
+	}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		s.mux.Lock()
+		s.requests = append(s.requests, fmt.Sprintf("%s %s %s", r.Method, r.URL.Path, r.Header.Get("Depth")))
+		if r.Method == "REPORT" {
+			s.bodies = append(s.bodies, string(body))
+		}
+		ctag := s.ctag
+		s.mux.Unlock()
+
+		if user, password, ok := r.BasicAuth(); !ok || user != "alice" || password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		var responses map[string]string
+		switch r.Method {
+		case "PROPFIND":
+			responses = propfind
+		case "REPORT":
+			responses = report
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		response, ok := responses[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+		w.WriteHeader(http.StatusMultiStatus)
+		_, _ = io.WriteString(w, multistatusHead+strings.ReplaceAll(response, "{ctag}", ctag)+multistatusTail)
+	}))
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+// calendar returns a CalDAV calendar pointing to the path of the stand-in
+func (s *caldavStandIn) calendar(path string, collection string) Calendar {
+	return Calendar{
+		Name:       "caldav",
+		From:       "caldav",
+		Ical:       s.URL + path,
+		Collection: collection,
+		Timezone:   "Europe/Berlin",
+		Auth:       CalendarAuth{Username: "alice", Password: Secret{Value: "secret"}},
+	}
+}
+
+func (s *caldavStandIn) served() []string {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	return append([]string(nil), s.requests...)
+}
+
+func TestCaldavCollectionDiscovery(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		collection string
+		want       string // path of the discovered collection, empty if discovery fails
+		requests   []string
+	}{
+		{
+			name: "server root",
+			path: "/",
+			want: "/calendars/alice/work/",
+			requests: []string{
+				"PROPFIND / 0",
+				"PROPFIND /principals/alice/ 0",
+				"PROPFIND /calendars/alice/ 1",
+			},
+		},
+		{
+			name:     "principal",
+			path:     "/principals/alice/",
+			want:     "/calendars/alice/work/",
+			requests: []string{"PROPFIND /principals/alice/ 0", "PROPFIND /calendars/alice/ 1"},
+		},
+		{
+			name:     "collection",
+			path:     "/calendars/alice/work/",
+			want:     "/calendars/alice/work/",
+			requests: []string{"PROPFIND /calendars/alice/work/ 0"},
+		},
+		{
+			name:       "by display name",
+			path:       "/principals/alice/",
+			collection: "Private",
+			want:       "/calendars/alice/private/",
+		},
+		{
+			name:       "by path segment",
+			path:       "/principals/alice/",
+			collection: "private",
+			want:       "/calendars/alice/private/",
+		},
+		{
+			name:       "collection without events",
+			path:       "/principals/alice/",
+			collection: "Tasks",
+		},
+		{
+			name:       "collection with a 404 propstat",
+			path:       "/principals/alice/",
+			collection: "gone",
+		},
+		{
+			name: "unknown URL",
+			path: "/nowhere/",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newCaldavStandIn(t)
+			e := NewICalClient(NewMemoryStatusStore())
+			cal := server.calendar(tt.path, tt.collection)
+
+			collection, err := e.caldavCollection(context.Background(), cal)
+			if tt.want == "" {
+				if err == nil {
+					t.Fatalf("expected discovery to fail, got %s", collection)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err.Display())
+			}
+
+			if collection != server.URL+tt.want {
+				t.Errorf("discovered %s, want %s", collection, server.URL+tt.want)
+			}
+
+			if tt.requests != nil && strings.Join(server.served(), ", ") != strings.Join(tt.requests, ", ") {
+				t.Errorf("served %v, want %v", server.served(), tt.requests)
+			}
+
+			// the collection is remembered
+			served := len(server.served())
+			if _, err := e.caldavCollection(context.Background(), cal); err != nil || len(server.served()) != served {
+				t.Errorf("the discovered collection wasn't reused")
+			}
+		})
+	}
+}
+
+func TestGetIcalFromCalDAV(t *testing.T) {
+	server := newCaldavStandIn(t)
+	e := NewICalClient(NewMemoryStatusStore())
+	cal := server.calendar("/", "")
+
+	start := time.Date(2025, time.April, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 1)
+
+	data, err := e.getIcalFromCalDAV(context.Background(), cal, start, end)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err.Display())
+	}
+
+	served := server.served()
+	if last := served[len(served)-1]; last != "REPORT /calendars/alice/work/ 1" {
+		t.Errorf("last request was %s, want the calendar-query REPORT", last)
+	}
+
+	if body := server.bodies[0]; !strings.Contains(body, `<c:time-range start="20250401T000000Z" end="20250402T000000Z"/>`) {
+		t.Errorf("REPORT doesn't filter by the window:\n%s", body)
+	}
+
+	events, err := safeIcalParse(cal, strings.NewReader(string(data.raw)), start, end)
+	if err != nil {
+		t.Fatalf("unable to parse the merged calendar data: %v", err.Display())
+	}
+
+	got := make([]string, 0, len(events))
+	for _, event := range events {
+		got = append(got, fmt.Sprintf("%s %s", event.Summary, event.Start.UTC().Format(time.RFC3339)))
+	}
+
+	want := []string{"Standup 2025-04-01T07:00:00Z", "Review 2025-04-01T12:00:00Z"}
+	if strings.Join(got, ", ") != strings.Join(want, ", ") {
+		t.Errorf("got events %v, want %v", got, want)
+	}
+}
+
+func TestGetIcalFromCalDAVMovedCollection(t *testing.T) {
+	server := newCaldavStandIn(t)
+	e := NewICalClient(NewMemoryStatusStore())
+	cal := server.calendar("/principals/alice/", "private")
+
+	// the REPORT on the private collection is answered with a 404
+	start := time.Date(2025, time.April, 1, 0, 0, 0, 0, time.UTC)
+	if _, err := e.getIcalFromCalDAV(context.Background(), cal, start, start.AddDate(0, 0, 1)); err == nil {
+		t.Fatal("expected the query of a missing collection to fail")
+	}
+
+	e.caldavMux.Lock()
+	_, cached := e.caldavCollections[caldavCacheKey(cal)]
+	e.caldavMux.Unlock()
+
+	if cached {
+		t.Error("the missing collection is still remembered, it should be discovered again")
+	}
+}
+
+func TestGetIcalFromCalDAVUnchangedCollection(t *testing.T) {
+	server := newCaldavStandIn(t)
+	server.ctag = "1"
+	e := NewICalClient(NewMemoryStatusStore())
+	cal := server.calendar("/calendars/alice/work/", "")
+
+	start := time.Date(2025, time.April, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 1)
+
+	reports := func() int {
+		n := 0
+		for _, request := range server.served() {
+			if strings.HasPrefix(request, "REPORT ") {
+				n++
+			}
+		}
+		return n
+	}
+
+	refresh := func() *icalData {
+		t.Helper()
+
+		data, err := e.getIcalFromCalDAV(context.Background(), cal, start, end)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err.Display())
+		}
+
+		if _, err := e.parseIcal(context.Background(), cal, data, start, end); err != nil {
+			t.Fatalf("unexpected error: %v", err.Display())
+		}
+
+		return data
+	}
+
+	first := refresh()
+	if reports() != 1 {
+		t.Fatalf("served %d REPORTs, want 1", reports())
+	}
+
+	// the collection didn't change, so the events aren't queried again
+	if second := refresh(); reports() != 1 || string(second.raw) != string(first.raw) {
+		t.Errorf("unchanged collection was queried again (%d REPORTs) or returned other data", reports())
+	}
+
+	// a different window has to be queried
+	end = end.AddDate(0, 0, 1)
+	if refresh(); reports() != 2 {
+		t.Errorf("served %d REPORTs after the window changed, want 2", reports())
+	}
+
+	server.mux.Lock()
+	server.ctag = "2"
+	server.mux.Unlock()
+
+	if refresh(); reports() != 3 {
+		t.Errorf("served %d REPORTs after the collection changed, want 3", reports())
+	}
+
+	// without a tag, the collection is queried on every refresh
+	server.mux.Lock()
+	server.ctag = ""
+	server.mux.Unlock()
+
+	refresh()
+	if refresh(); reports() != 5 {
+		t.Errorf("served %d REPORTs without a tag, want 5", reports())
+	}
+}
+
+func TestMergeCalendarData(t *testing.T) {
+	merged := string(mergeCalendarData([]string{
+		caldavObject("one@stand-in", "One", "20250401T090000"),
+		strings.ReplaceAll(caldavObject("two@stand-in", "Two", "20250401T100000"), "\r\n", "\n"),
+	}))
+
+	for _, want := range []string{"BEGIN:VCALENDAR", "END:VCALENDAR", "VERSION:2.0"} {
+		if n := strings.Count(merged, want+"\r\n"); n != 1 {
+			t.Errorf("%s appears %d times, want once", want, n)
+		}
+	}
+
+	for _, dropped := range []string{"PRODID:-//Stand-In", "X-WR-CALNAME", "continuation line"} {
+		if strings.Contains(merged, dropped) {
+			t.Errorf("the objects' calendar property %q wasn't dropped", dropped)
+		}
+	}
+
+	for _, kept := range []string{"UID:one@stand-in", "UID:two@stand-in", "RRULE:FREQ=YEARLY;BYMONTH=3;BYDAY=-1SU"} {
+		if !strings.Contains(merged, kept+"\r\n") {
+			t.Errorf("%q is missing from the merged calendar", kept)
+		}
+	}
+
+	if strings.Count(merged, "BEGIN:VEVENT") != 2 || strings.Count(merged, "END:VTIMEZONE") != 2 {
+		t.Errorf("the components weren't merged:\n%s", merged)
+	}
+
+	if strings.Contains(strings.ReplaceAll(merged, "\r\n", ""), "\n") {
+		t.Error("the merged calendar mixes line endings")
+	}
+}
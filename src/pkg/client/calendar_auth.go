@@ -0,0 +1,201 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/sierrasoftworks/humane-errors-go"
+)
+
+// defaultUserAgent is sent to remote calendars unless a 'User-Agent' header is configured.
+// Some iCal exports refuse to serve non-browser clients.
+const defaultUserAgent = "Mozilla/5.0 (X11; Linux x86_64; rv:60.0) Gecko/20100101 Firefox/81.0"
+
+// Secret is a configuration value that is either given inline or read from an environment
+// variable or a file when it is needed. This keeps credentials out of the config file.
+// In the config a Secret is either a plain string or a map with one of 'value', 'env' or 'file'.
+type Secret struct {
+	Value string `mapstructure:"value"`
+	Env   string `mapstructure:"env"`
+	File  string `mapstructure:"file"`
+}
+
+// IsSet reports whether the secret is configured at all
+func (s Secret) IsSet() bool {
+	return s.Value != "" || s.Env != "" || s.File != ""
+}
+
+// Resolve returns the value of the secret
+func (s Secret) Resolve() (string, humane.Error) {
+	switch {
+	case s.Env != "":
+		value, ok := os.LookupEnv(s.Env)
+		if !ok {
+			return "", humane.New(fmt.Sprintf("environment variable %s is not set", s.Env), "set the environment variable or change the secret's 'env' setting")
+		}
+		return value, nil
+
+	case s.File != "":
+		value, err := os.ReadFile(s.File)
+		if err != nil {
+			return "", humane.Wrap(err, fmt.Sprintf("unable to read secret file %s", s.File), "check if file path exists and is accessible")
+		}
+		return strings.TrimSpace(string(value)), nil
+
+	default:
+		return s.Value, nil
+	}
+}
+
+// secretDecodeHook allows secrets to be configured as plain strings
+func secretDecodeHook(from reflect.Type, to reflect.Type, data any) (any, error) {
+	if from.Kind() != reflect.String || to != reflect.TypeOf(Secret{}) {
+		return data, nil
+	}
+
+	return Secret{Value: data.(string)}, nil
+}
+
+// withSecretDecodeHook adds secretDecodeHook to viper's default decode hooks
+func withSecretDecodeHook(c *mapstructure.DecoderConfig) {
+	c.DecodeHook = mapstructure.ComposeDecodeHookFunc(c.DecodeHook, secretDecodeHook)
+}
+
+// CalendarAuth configures how CalendarAPI authenticates against a remote calendar.
+// Setting a username enables HTTP Basic auth, setting a token enables bearer auth.
+type CalendarAuth struct {
+	Username string `mapstructure:"username"`
+	Password Secret `mapstructure:"password"`
+	Token    Secret `mapstructure:"token"`
+}
+
+// CalendarTLS configures the TLS connection to a remote calendar
+type CalendarTLS struct {
+	CA                 string `mapstructure:"ca"`   // PEM file with additional CAs to trust
+	Cert               string `mapstructure:"cert"` // PEM file with the client certificate
+	Key                string `mapstructure:"key"`  // PEM file with the client certificate's private key
+	InsecureSkipVerify bool   `mapstructure:"insecureSkipVerify"`
+}
+
+// IsSet reports whether any TLS setting differs from Go's defaults
+func (t CalendarTLS) IsSet() bool {
+	return t.CA != "" || t.Cert != "" || t.Key != "" || t.InsecureSkipVerify
+}
+
+// authorizeRequest adds the configured headers and credentials of the calendar to the request
+func authorizeRequest(req *http.Request, cal Calendar) humane.Error {
+	req.Header.Set("User-Agent", defaultUserAgent)
+
+	for name, secret := range cal.Headers {
+		value, err := secret.Resolve()
+		if err != nil {
+			return humane.Wrap(err, fmt.Sprintf("unable to resolve header %s", name))
+		}
+		req.Header.Set(name, value)
+	}
+
+	if cal.Auth.Username != "" && cal.Auth.Token.IsSet() {
+		return humane.New("both basic and bearer auth are configured", "configure either 'username' and 'password' or 'token' in the calendar's 'auth' section")
+	}
+
+	if cal.Auth.Username != "" {
+		password, err := cal.Auth.Password.Resolve()
+		if err != nil {
+			return humane.Wrap(err, "unable to resolve password")
+		}
+		req.SetBasicAuth(cal.Auth.Username, password)
+	}
+
+	if cal.Auth.Token.IsSet() {
+		token, err := cal.Auth.Token.Resolve()
+		if err != nil {
+			return humane.Wrap(err, "unable to resolve bearer token")
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	return nil
+}
+
+// calendarHTTPClient returns the HTTP client used to talk to the calendar. Calendars without TLS
+// settings share the client's default HTTP client, all others get their own, cached, client.
+func (e *ICalClient) calendarHTTPClient(cal Calendar) (*http.Client, humane.Error) {
+	if !cal.TLS.IsSet() {
+		return e.httpClient, nil
+	}
+
+	key := httpClientKey(cal)
+
+	e.httpClientsMux.Lock()
+	defer e.httpClientsMux.Unlock()
+
+	if client, ok := e.httpClients[key]; ok {
+		return client, nil
+	}
+
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: cal.TLS.InsecureSkipVerify, //nolint:gosec // explicitly configured by the user
+	}
+
+	if cal.TLS.CA != "" {
+		pem, err := os.ReadFile(cal.TLS.CA)
+		if err != nil {
+			return nil, humane.Wrap(err, fmt.Sprintf("unable to read CA file %s", cal.TLS.CA), "check if file path exists and is accessible")
+		}
+
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, humane.New(fmt.Sprintf("no certificates found in CA file %s", cal.TLS.CA), "make sure the CA file contains PEM encoded certificates")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if cal.TLS.Cert != "" || cal.TLS.Key != "" {
+		if cal.TLS.Cert == "" || cal.TLS.Key == "" {
+			return nil, humane.New("incomplete client certificate", "configure both 'cert' and 'key' in the calendar's 'tls' section")
+		}
+
+		if _, err := tls.LoadX509KeyPair(cal.TLS.Cert, cal.TLS.Key); err != nil {
+			return nil, humane.Wrap(err, "unable to load client certificate", "make sure 'cert' and 'key' are PEM encoded and belong together")
+		}
+
+		// load the certificate on every handshake, so rotated certificates are picked up
+		tlsConfig.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			cert, err := tls.LoadX509KeyPair(cal.TLS.Cert, cal.TLS.Key)
+			return &cert, err
+		}
+	}
+
+	transport, ok := e.httpClient.Transport.(*http.Transport)
+	if !ok || transport == nil {
+		transport = http.DefaultTransport.(*http.Transport)
+	}
+	transport = transport.Clone()
+	transport.TLSClientConfig = tlsConfig
+
+	client := &http.Client{
+		Transport:     transport,
+		CheckRedirect: e.httpClient.CheckRedirect,
+		Jar:           e.httpClient.Jar,
+		Timeout:       e.httpClient.Timeout,
+	}
+	e.httpClients[key] = client
+
+	return client, nil
+}
+
+// httpClientKey identifies the HTTP client of a calendar with TLS settings, a client is only
+// reused as long as the calendar's TLS settings don't change
+func httpClientKey(cal Calendar) string {
+	return fmt.Sprintf("%s|%+v", cal.Name, cal.TLS)
+}
@@ -0,0 +1,36 @@
+package client
+
+import "testing"
+
+func TestPruneHTTPClients(t *testing.T) {
+	e := NewICalClient(NewMemoryStatusStore())
+	cal := Calendar{Name: "work", From: "url", Ical: "https://calendar.example.com/work.ics", TLS: CalendarTLS{InsecureSkipVerify: true}}
+
+	client, err := e.calendarHTTPClient(cal)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err.Display())
+	}
+
+	// the client is kept as long as the calendar's TLS settings stay the same
+	e.pruneCalendarStates([]Calendar{cal})
+	if kept, _ := e.calendarHTTPClient(cal); kept != client {
+		t.Error("the client of an unchanged calendar was replaced")
+	}
+
+	changed := cal
+	changed.TLS.InsecureSkipVerify = false
+	changed.TLS.CA = "/etc/ssl/certs/ca.pem"
+	e.pruneCalendarStates([]Calendar{changed})
+	if len(e.httpClients) != 0 {
+		t.Errorf("%d clients left after the TLS settings changed, want none", len(e.httpClients))
+	}
+
+	if _, err := e.calendarHTTPClient(cal); err != nil {
+		t.Fatalf("unexpected error: %v", err.Display())
+	}
+
+	e.pruneCalendarStates(nil)
+	if len(e.httpClients) != 0 {
+		t.Errorf("%d clients left after the calendar was removed, want none", len(e.httpClients))
+	}
+}
@@ -0,0 +1,110 @@
+package client
+
+import (
+	"time"
+
+	"github.com/apognu/gocal"
+
+	pb "github.com/SpechtLabs/CalendarAPI/pkg/protos"
+)
+
+// icalData is the raw content of an iCal file along with the HTTP cache validators it was served
+// with. For CalDAV calendars, etag holds the tag of the collection.
+type icalData struct {
+	raw          []byte
+	etag         string
+	lastModified string
+}
+
+// calendarState is what ICalClient remembers about a calendar between two refreshes.
+// States are never modified in place, updates always replace the whole state.
+type calendarState struct {
+	source string // 'from' and 'ical' of the calendar the state belongs to
+
+	// raw cache of the last successfully parsed iCal data
+	ical icalData
+
+	// events parsed from the raw cache for the window [windowStart, windowEnd)
+	windowStart time.Time
+	windowEnd   time.Time
+	location    string            // the calendar's time zone when parsing
+	timezones   map[string]string // the time zone overrides in effect when parsing
+	events      []gocal.Event
+
+	// last known good entries, served while the calendar can't be refreshed
+	entries     []*pb.CalendarEntry
+	lastSuccess time.Time
+}
+
+func calendarSource(cal Calendar) string {
+	return cal.From + "|" + cal.Ical
+}
+
+// getCalendarState returns the state of the calendar, or nil if there is none or it belongs to
+// a different source (e.g. because the config changed)
+func (e *ICalClient) getCalendarState(cal Calendar) *calendarState {
+	e.statesMux.RLock()
+	defer e.statesMux.RUnlock()
+
+	state, ok := e.states[cal.Name]
+	if !ok || state.source != calendarSource(cal) {
+		return nil
+	}
+
+	return state
+}
+
+// updateCalendarState applies update to a copy of the calendar's state and stores the result
+func (e *ICalClient) updateCalendarState(cal Calendar, update func(state *calendarState)) {
+	e.statesMux.Lock()
+	defer e.statesMux.Unlock()
+
+	state := calendarState{source: calendarSource(cal)}
+	if old, ok := e.states[cal.Name]; ok && old.source == state.source {
+		state = *old
+	}
+
+	update(&state)
+	e.states[cal.Name] = &state
+}
+
+// pruneCalendarStates forgets the state of calendars that are no longer configured, as well as
+// the HTTP clients of calendars that are gone or whose TLS settings changed
+func (e *ICalClient) pruneCalendarStates(calendars []Calendar) {
+	configured := make(map[string]bool, len(calendars))
+	clients := make(map[string]bool, len(calendars))
+	for _, cal := range calendars {
+		configured[cal.Name] = true
+		clients[httpClientKey(cal)] = true
+	}
+
+	e.statesMux.Lock()
+	for name := range e.states {
+		if !configured[name] {
+			delete(e.states, name)
+		}
+	}
+	e.statesMux.Unlock()
+
+	e.httpClientsMux.Lock()
+	defer e.httpClientsMux.Unlock()
+
+	for key, client := range e.httpClients {
+		if !clients[key] {
+			client.CloseIdleConnections()
+			delete(e.httpClients, key)
+		}
+	}
+}
+
+// eventsInWindow returns the entries overlapping the window [windowStart, windowEnd)
+func eventsInWindow(entries []*pb.CalendarEntry, windowStart time.Time, windowEnd time.Time) []*pb.CalendarEntry {
+	result := make([]*pb.CalendarEntry, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Start < windowEnd.Unix() && entry.End > windowStart.Unix() {
+			result = append(result, entry)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,285 @@
+package client
+
+import (
+	"fmt"
+	"regexp/syntax"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/sierrasoftworks/humane-errors-go"
+	"github.com/spf13/viper"
+)
+
+// ValidateConfig checks the calendars, rules and server settings of the configuration. Errors
+// make the configuration unusable, warnings point out settings that most likely don't do what
+// was intended.
+func ValidateConfig(v *viper.Viper) (errs []humane.Error, warnings []humane.Error) {
+	calendars, err := unmarshalCalendars(v)
+	if err != nil {
+		errs = append(errs, humane.Wrap(err, "unable to parse calendars", "check the 'calendars' section against the documentation"))
+	}
+
+	names := make(map[string]bool, len(calendars))
+	for i, cal := range calendars {
+		if cal.Name == "" {
+			errs = append(errs, humane.New(fmt.Sprintf("calendar #%d has no name", i+1), "give every calendar a unique 'name'"))
+		} else if names[cal.Name] {
+			errs = append(errs, humane.New(fmt.Sprintf("calendar %s is configured more than once", cal.Name), "give every calendar a unique 'name'"))
+		}
+		names[cal.Name] = true
+
+		switch cal.From {
+		case "file", "url", "caldav":
+		default:
+			errs = append(errs, humane.New(fmt.Sprintf("calendar %s has unsupported 'from' type '%s'", cal.Name, cal.From),
+				"The only supported values for 'from' are 'file', 'url' or 'caldav'",
+			))
+		}
+
+		if cal.Ical == "" {
+			errs = append(errs, humane.New(fmt.Sprintf("calendar %s has no 'ical' source", cal.Name), "set 'ical' to the path or URL of the calendar"))
+		}
+
+		if cal.Timezone != "" {
+			if _, err := time.LoadLocation(cal.Timezone); err != nil {
+				errs = append(errs, humane.Wrap(err, fmt.Sprintf("calendar %s has an unknown timezone", cal.Name), "set 'timezone' to an IANA time zone like 'Europe/Berlin'"))
+			}
+		}
+	}
+
+	// this also keeps half-written config files from being loaded
+	if len(calendars) == 0 && err == nil {
+		errs = append(errs, humane.New("no calendars are configured", "add your calendars to the 'calendars' section"))
+	}
+
+	rules, err := unmarshalRules(v)
+	if err != nil {
+		errs = append(errs, humane.Wrap(err, "unable to parse rules", "check the 'rules' section against the documentation"))
+	}
+
+	catchAll := ""                          // the rule matching all events of all calendars
+	calendarCatchAll := map[string]string{} // the rules matching all events of a calendar
+	for i := range rules {
+		rule := &rules[i]
+		if err := rule.compile(); err != nil {
+			errs = append(errs, humane.Wrap(err, fmt.Sprintf("rule %s is invalid", rule.Name), "fix the rule, configurations with invalid rules are rejected"))
+		}
+
+		for _, key := range rule.unknownKeys() {
+			errs = append(errs, humane.New(fmt.Sprintf("rule %s matches on unknown key '%s'", rule.Name, key),
+				fmt.Sprintf("use one of %s, calendar, any_field or *", strings.Join(fieldKeys, ", ")),
+			))
+		}
+
+		allCalendars := rule.CalendarName == "" || rule.CalendarName == "*" || rule.CalendarName == "all"
+		if !allCalendars && !names[rule.CalendarName] {
+			errs = append(errs, humane.New(fmt.Sprintf("rule %s refers to calendar %s, which is not configured", rule.Name, rule.CalendarName),
+				"set 'calendar' to the name of a configured calendar, or leave it empty to apply the rule to all calendars",
+			))
+		}
+
+		shadowedBy := catchAll
+		if shadowedBy == "" && !allCalendars {
+			shadowedBy = calendarCatchAll[rule.CalendarName]
+		}
+
+		if shadowedBy != "" {
+			warnings = append(warnings, humane.New(fmt.Sprintf("rule %s is never applied, rule %s before it matches all events", rule.Name, shadowedBy),
+				"only the first matching rule is applied, move catch-all rules to the end",
+			))
+		}
+
+		if rule.matchesAll() {
+			if allCalendars && catchAll == "" {
+				catchAll = rule.Name
+			} else if !allCalendars && calendarCatchAll[rule.CalendarName] == "" {
+				calendarCatchAll[rule.CalendarName] = rule.Name
+			}
+		}
+	}
+
+	if refresh := v.GetString("server.refresh"); refresh != "" {
+		if _, err := time.ParseDuration(refresh); err != nil {
+			errs = append(errs, humane.Wrap(err, "'server.refresh' is not a valid duration", "use a Go duration like '30m'"))
+		}
+	}
+
+	if timezone := v.GetString("server.timezone"); timezone != "" {
+		if _, err := time.LoadLocation(timezone); err != nil {
+			errs = append(errs, humane.Wrap(err, "'server.timezone' is not a known time zone", "use an IANA time zone like 'Europe/Berlin'"))
+		}
+	}
+
+	switch storeType := v.GetString("server.statusStore.type"); storeType {
+	case "", "memory":
+	case "file":
+		if v.GetString("server.statusStore.path") == "" {
+			errs = append(errs, humane.New("the file status store has no path", "set 'server.statusStore.path' to the JSON file to store statuses in"))
+		}
+	default:
+		errs = append(errs, humane.New(fmt.Sprintf("unknown status store type %s", storeType), "set 'server.statusStore.type' to either 'memory' or 'file'"))
+	}
+
+	overrides, err := unmarshalTimezoneOverrides(v)
+	if err != nil {
+		errs = append(errs, humane.Wrap(err, "unable to parse timezones", "check the 'timezones' section against the documentation"))
+	}
+
+	tzids := make(map[string]bool, len(overrides))
+	for i, override := range overrides {
+		if override.TZID == "" {
+			errs = append(errs, humane.New(fmt.Sprintf("time zone override #%d has no tzid", i+1), "set 'tzid' to the TZID used in the iCal file"))
+			continue
+		}
+
+		if tzids[override.TZID] {
+			errs = append(errs, humane.New(fmt.Sprintf("time zone %s is overridden more than once", override.TZID), "only override every TZID once"))
+		}
+		tzids[override.TZID] = true
+
+		if _, err := time.LoadLocation(override.Location); err != nil || override.Location == "" {
+			errs = append(errs, humane.New(fmt.Sprintf("time zone %s is mapped to unknown location '%s'", override.TZID, override.Location),
+				"set 'location' to an IANA time zone like 'Europe/Berlin'",
+			))
+		}
+	}
+
+	keys, err := UnmarshalAPIKeys(v)
+	if err != nil {
+		errs = append(errs, humane.Wrap(err, "unable to parse API keys", "check the 'server.auth' section against the documentation"))
+	}
+
+	keyIDs := make(map[string]bool, len(keys))
+	for i, key := range keys {
+		id := key.ID
+		if id == "" {
+			id = fmt.Sprintf("#%d", i+1)
+			errs = append(errs, humane.New(fmt.Sprintf("API key %s has no id", id), "give every API key a unique 'id', it names the caller in logs and traces"))
+		} else if keyIDs[id] {
+			errs = append(errs, humane.New(fmt.Sprintf("API key %s is configured more than once", id), "give every API key a unique 'id'"))
+		}
+		keyIDs[id] = true
+
+		if !key.Key.IsSet() {
+			errs = append(errs, humane.New(fmt.Sprintf("API key %s has no key", id), "set 'key' to the secret callers present"))
+		}
+
+		if len(key.Scopes) == 0 {
+			warnings = append(warnings, humane.New(fmt.Sprintf("API key %s has no scopes", id), fmt.Sprintf("grant it some of %s", strings.Join(Scopes, ", "))))
+		}
+
+		for _, scope := range key.Scopes {
+			if !slices.Contains(Scopes, scope) {
+				errs = append(errs, humane.New(fmt.Sprintf("API key %s has unknown scope '%s'", id, scope), fmt.Sprintf("use one of %s", strings.Join(Scopes, ", "))))
+			}
+		}
+	}
+
+	anonymousScopes := v.GetStringSlice("server.auth.anonymousScopes")
+	for _, scope := range anonymousScopes {
+		if !slices.Contains(Scopes, scope) {
+			errs = append(errs, humane.New(fmt.Sprintf("'server.auth.anonymousScopes' has unknown scope '%s'", scope), fmt.Sprintf("use one of %s", strings.Join(Scopes, ", "))))
+		}
+	}
+
+	if len(keys) == 0 && len(anonymousScopes) > 0 {
+		warnings = append(warnings, humane.New("'server.auth.anonymousScopes' has no effect without API keys",
+			"without 'server.auth.keys' all callers may use the whole API, configure keys to limit them",
+		))
+	}
+
+	return errs, warnings
+}
+
+// matchesAll reports whether the condition matches every event: it isn't negated and all its
+// matchers match any value, like contains "*" or "", a regex like ".*" on a field that always has
+// a value, or nested conditions that match all events themselves
+func (c *Condition) matchesAll() bool {
+	if c.Not || len(c.NotContains) > 0 || len(c.Equals) > 0 {
+		return false
+	}
+
+	matchers := 0
+
+	if len(c.Contains) > 0 {
+		matchers++
+		if !slices.ContainsFunc(c.Contains, func(contains string) bool { return contains == "*" || contains == "" }) {
+			return false
+		}
+	}
+
+	if len(c.Regex) > 0 {
+		matchers++
+		if !slices.Contains(singleValueKeys, c.Key) || !slices.ContainsFunc(c.Regex, matchesEverything) {
+			return false
+		}
+	}
+
+	if len(c.All) > 0 {
+		matchers++
+		if slices.ContainsFunc(c.All, func(sub Condition) bool { return !sub.matchesAll() }) {
+			return false
+		}
+	}
+
+	if len(c.Any) > 0 {
+		matchers++
+		if !slices.ContainsFunc(c.Any, func(sub Condition) bool { return sub.matchesAll() }) {
+			return false
+		}
+	}
+
+	return matchers > 0
+}
+
+// singleValueKeys are the keys that refer to exactly one value for every event. Organizers,
+// attendees and categories may be missing, so not even ".*" matches every event on them.
+var singleValueKeys = []string{"title", "all_day", "busy", "calendar", "location", "description", "uid", "url", "*"}
+
+// matchesEverything reports whether the regular expression matches every string. Conditions look
+// for matches anywhere in the value, so that's the case if it matches the empty string at the start.
+func matchesEverything(expr string) bool {
+	re, err := syntax.Parse(expr, syntax.Perl)
+	if err != nil {
+		return false
+	}
+
+	return matchesEmptyAtStart(re.Simplify())
+}
+
+// matchesEmptyAtStart reports whether the expression matches the empty string at the start of a string
+func matchesEmptyAtStart(re *syntax.Regexp) bool {
+	switch re.Op {
+	case syntax.OpEmptyMatch, syntax.OpBeginLine, syntax.OpBeginText, syntax.OpStar, syntax.OpQuest:
+		return true
+
+	case syntax.OpRepeat:
+		return re.Min == 0 || matchesEmptyAtStart(re.Sub[0])
+
+	case syntax.OpPlus, syntax.OpCapture:
+		return matchesEmptyAtStart(re.Sub[0])
+
+	case syntax.OpConcat:
+		return !slices.ContainsFunc(re.Sub, func(sub *syntax.Regexp) bool { return !matchesEmptyAtStart(sub) })
+
+	case syntax.OpAlternate:
+		return slices.ContainsFunc(re.Sub, matchesEmptyAtStart)
+	}
+
+	return false
+}
+
+// unknownKeys returns the keys of the condition and its nested conditions that don't refer to a field
+func (c *Condition) unknownKeys() []string {
+	var unknown []string
+	if c.Key != "" && c.Key != "*" && c.Key != "calendar" && c.Key != "any_field" && !slices.Contains(fieldKeys, c.Key) {
+		unknown = append(unknown, c.Key)
+	}
+
+	for _, sub := range slices.Concat(c.All, c.Any) {
+		unknown = append(unknown, sub.unknownKeys()...)
+	}
+
+	return unknown
+}
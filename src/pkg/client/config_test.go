@@ -0,0 +1,70 @@
+package client
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestConditionMatchesAll(t *testing.T) {
+	tests := []struct {
+		name      string
+		condition Condition
+		want      bool
+	}{
+		{name: "no matcher", condition: Condition{Key: "*"}},
+		{name: "contains wildcard", condition: Condition{Key: "*", Contains: []string{"*"}}, want: true},
+		{name: "contains empty string", condition: Condition{Key: "organizer", Contains: []string{"sync", ""}}, want: true},
+		{name: "contains text", condition: Condition{Key: "title", Contains: []string{"sync"}}},
+		{name: "empty not_contains", condition: Condition{Key: "title", Contains: []string{"*"}, NotContains: []string{}}, want: true},
+		{name: "not_contains", condition: Condition{Key: "title", Contains: []string{"*"}, NotContains: []string{"sync"}}},
+		{name: "equals", condition: Condition{Key: "title", Equals: []string{""}}},
+		{name: "negated", condition: Condition{Key: "title", Contains: []string{"*"}, Not: true}},
+
+		{name: "regex .*", condition: Condition{Key: "title", Regex: []string{".*"}}, want: true},
+		{name: "anchored regex ^.*", condition: Condition{Key: "*", Regex: []string{"^(?s)(.*)"}}, want: true},
+		{name: "empty regex", condition: Condition{Key: "uid", Regex: []string{""}}, want: true},
+		{name: "optional regex", condition: Condition{Key: "title", Regex: []string{"(standup)?"}}, want: true},
+		{name: "one of the regexes", condition: Condition{Key: "title", Regex: []string{"standup", "x{0,3}"}}, want: true},
+		{name: "regex with text", condition: Condition{Key: "title", Regex: []string{".*standup"}}},
+		{name: "regex .+", condition: Condition{Key: "title", Regex: []string{".+"}}},
+		{name: "regex ^$", condition: Condition{Key: "title", Regex: []string{"^$"}}},
+		{name: "regex on a field that may be missing", condition: Condition{Key: "attendees", Regex: []string{".*"}}},
+		{name: "invalid regex", condition: Condition{Key: "title", Regex: []string{"(.*"}}},
+
+		{name: "all match everything", condition: Condition{All: []Condition{{Key: "title", Contains: []string{"*"}}, {Key: "busy", Regex: []string{".*"}}}}, want: true},
+		{name: "all with a filter", condition: Condition{All: []Condition{{Key: "title", Contains: []string{"*"}}, {Key: "busy", Equals: []string{"Free"}}}}},
+		{name: "any matches everything", condition: Condition{Any: []Condition{{Key: "busy", Equals: []string{"Free"}}, {Key: "title", Contains: []string{""}}}}, want: true},
+		{name: "any with filters", condition: Condition{Any: []Condition{{Key: "busy", Equals: []string{"Free"}}, {Key: "title", Contains: []string{"sync"}}}}},
+		{name: "contains and any", condition: Condition{Key: "title", Contains: []string{"sync"}, Any: []Condition{{Key: "title", Contains: []string{"*"}}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.condition.matchesAll(); got != tt.want {
+				t.Errorf("matchesAll() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateConfigRules(t *testing.T) {
+	v := viper.New()
+	v.Set("calendars", []map[string]any{{"name": "work", "from": "file", "ical": "work.ics"}})
+	v.Set("rules", []map[string]any{
+		{"name": "broken", "key": "title", "regex": []string{"(sync"}},
+		{"name": "everything", "key": "title", "regex": []string{".*"}},
+		{"name": "shadowed", "key": "title", "contains": []string{"sync"}},
+	})
+
+	errs, warnings := ValidateConfig(v)
+
+	if len(errs) != 1 || !strings.Contains(errs[0].Display(), "rule broken is invalid") || !strings.Contains(errs[0].Display(), "rejected") {
+		t.Errorf("unexpected errors %v", errs)
+	}
+
+	if len(warnings) != 1 || !strings.Contains(warnings[0].Error(), "rule shadowed is never applied, rule everything before it matches all events") {
+		t.Errorf("unexpected warnings %v", warnings)
+	}
+}
@@ -0,0 +1,183 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sierrasoftworks/humane-errors-go"
+	"github.com/spechtlabs/go-otel-utils/otelzap"
+	"go.opentelemetry.io/otel/attribute"
+
+	pb "github.com/SpechtLabs/CalendarAPI/pkg/protos"
+)
+
+// busyStrength ranks the busy states, the strongest state of overlapping events wins
+var busyStrength = map[pb.BusyState]int{
+	pb.BusyState_Free:             0,
+	pb.BusyState_WorkingElsewhere: 1,
+	pb.BusyState_Tentative:        2,
+	pb.BusyState_Busy:             3,
+	pb.BusyState_OutOfOffice:      4,
+}
+
+// freeBusyTypes maps the busy states to the FBTYPE of VFREEBUSY periods
+var freeBusyTypes = map[pb.BusyState]string{
+	pb.BusyState_WorkingElsewhere: "X-WORKINGELSEWHERE",
+	pb.BusyState_Tentative:        "BUSY-TENTATIVE",
+	pb.BusyState_Busy:             "BUSY",
+	pb.BusyState_OutOfOffice:      "BUSY-UNAVAILABLE",
+}
+
+// GetFreeBusy merges the cached events of the calendars (all calendars if empty) that overlap
+// the time range [from, to) into a single free/busy timeline. A zero from or to defaults to the
+// respective boundary of the cached window. Only the times and busy states of events are
+// exposed, so private events don't need to be masked.
+func (e *ICalClient) GetFreeBusy(ctx context.Context, calendars []string, from int64, to int64) (*pb.FreeBusyResponse, humane.Error) {
+	ctx, span := e.tracer.Start(ctx, "ICalClient.GetFreeBusy")
+	defer span.End()
+
+	span.SetAttributes(attribute.StringSlice("calendars", calendars))
+
+	if e.cache == nil {
+		otelzap.L().Ctx(ctx).Info("Experiencing cold. Fetching events now!")
+		e.FetchEvents(ctx)
+	}
+
+	e.cacheMux.RLock()
+	defer e.cacheMux.RUnlock()
+
+	from, to, err := e.cachedRange(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	calendars, entries, err := e.cachedEntries(calendars)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.FreeBusyResponse{
+		CalendarNames: calendars,
+		From:          from,
+		To:            to,
+		Intervals:     mergeFreeBusy(entries, from, to),
+		LastUpdated:   e.cache.LastUpdated,
+	}, nil
+}
+
+// cachedEntries returns the cached entries of the calendars (all calendars if empty or "all"),
+// rejecting calendars that aren't configured. The caller must hold the cacheMux.
+func (e *ICalClient) cachedEntries(calendars []string) ([]string, []*pb.CalendarEntry, humane.Error) {
+	configured := make([]string, 0, len(e.cache.Calendars))
+	for _, info := range e.cache.Calendars {
+		configured = append(configured, info.Name)
+	}
+
+	if len(calendars) == 0 || slices.Contains(calendars, "all") || slices.Contains(calendars, "*") {
+		calendars = configured
+	}
+
+	for _, calendar := range calendars {
+		if !slices.Contains(configured, calendar) {
+			return nil, nil, humane.New(fmt.Sprintf("calendar %s is not configured", calendar),
+				fmt.Sprintf("use the names of the configured calendars: %s", strings.Join(configured, ", ")),
+			)
+		}
+	}
+
+	entries := make([]*pb.CalendarEntry, 0, len(e.cache.Entries))
+	for _, entry := range e.cache.Entries {
+		if slices.Contains(calendars, entry.CalendarName) {
+			entries = append(entries, entry)
+		}
+	}
+
+	return calendars, entries, nil
+}
+
+// mergeFreeBusy merges the entries into non-overlapping intervals within [from, to), each with the
+// strongest busy state of the entries during it. Free entries are left out.
+func mergeFreeBusy(entries []*pb.CalendarEntry, from int64, to int64) []*pb.FreeBusyInterval {
+	type edge struct {
+		at    int64
+		busy  pb.BusyState
+		delta int
+	}
+
+	edges := make([]edge, 0, 2*len(entries))
+	for _, entry := range entries {
+		start, end := max(entry.Start, from), min(entry.End, to)
+		if entry.Busy == pb.BusyState_Free || start >= end {
+			continue
+		}
+
+		edges = append(edges, edge{at: start, busy: entry.Busy, delta: 1}, edge{at: end, busy: entry.Busy, delta: -1})
+	}
+
+	sort.Slice(edges, func(i int, j int) bool {
+		return edges[i].at < edges[j].at
+	})
+
+	intervals := make([]*pb.FreeBusyInterval, 0)
+	active := make(map[pb.BusyState]int, len(busyStrength))
+
+	var open *pb.FreeBusyInterval
+	for i := 0; i < len(edges); {
+		// apply all edges at the same time before deciding about the state
+		at := edges[i].at
+		for ; i < len(edges) && edges[i].at == at; i++ {
+			active[edges[i].busy] += edges[i].delta
+		}
+
+		state := pb.BusyState_Free
+		for busy, count := range active {
+			if count > 0 && busyStrength[busy] > busyStrength[state] {
+				state = busy
+			}
+		}
+
+		if open != nil && open.Busy == state {
+			continue
+		}
+
+		if open != nil {
+			open.End = at
+			intervals = append(intervals, open)
+			open = nil
+		}
+
+		if state != pb.BusyState_Free {
+			open = &pb.FreeBusyInterval{Start: at, Busy: state}
+		}
+	}
+
+	return intervals
+}
+
+// MarshalFreeBusyICal renders the free/busy timeline as RFC 5545 VFREEBUSY iCal document
+func MarshalFreeBusyICal(freeBusy *pb.FreeBusyResponse) []byte {
+	var w icalWriter
+
+	w.line("BEGIN", "VCALENDAR")
+	w.line("VERSION", "2.0")
+	w.line("PRODID", "-//SpechtLabs//CalendarAPI//EN")
+	w.line("METHOD", "PUBLISH")
+	w.line("BEGIN", "VFREEBUSY")
+	w.line("UID", fmt.Sprintf("freebusy-%s-%d-%d@calendarapi", strings.Join(freeBusy.CalendarNames, "+"), freeBusy.From, freeBusy.To))
+	w.line("DTSTAMP", icalTime(time.Now().Unix()))
+	w.line("DTSTART", icalTime(freeBusy.From))
+	w.line("DTEND", icalTime(freeBusy.To))
+
+	for _, interval := range freeBusy.Intervals {
+		w.line("FREEBUSY;FBTYPE="+freeBusyTypes[interval.Busy], icalTime(interval.Start)+"/"+icalTime(interval.End))
+	}
+
+	w.line("END", "VFREEBUSY")
+	w.line("END", "VCALENDAR")
+
+	return w.Bytes()
+}
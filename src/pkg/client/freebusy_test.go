@@ -0,0 +1,106 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	pb "github.com/SpechtLabs/CalendarAPI/pkg/protos"
+)
+
+func TestMergeFreeBusy(t *testing.T) {
+	entry := func(start int64, end int64, busy pb.BusyState) *pb.CalendarEntry {
+		return &pb.CalendarEntry{Start: start, End: end, Busy: busy}
+	}
+
+	tests := []struct {
+		name    string
+		entries []*pb.CalendarEntry
+		want    string
+	}{
+		{
+			name: "no entries",
+			want: "",
+		},
+		{
+			name:    "free entries",
+			entries: []*pb.CalendarEntry{entry(10, 20, pb.BusyState_Free)},
+			want:    "",
+		},
+		{
+			name:    "touching entries",
+			entries: []*pb.CalendarEntry{entry(10, 20, pb.BusyState_Busy), entry(20, 30, pb.BusyState_Busy)},
+			want:    "10-30 Busy",
+		},
+		{
+			name:    "touching entries of different states",
+			entries: []*pb.CalendarEntry{entry(10, 20, pb.BusyState_Busy), entry(20, 30, pb.BusyState_Tentative)},
+			want:    "10-20 Busy, 20-30 Tentative",
+		},
+		{
+			name:    "overlapping entries",
+			entries: []*pb.CalendarEntry{entry(10, 25, pb.BusyState_Busy), entry(20, 30, pb.BusyState_Busy)},
+			want:    "10-30 Busy",
+		},
+		{
+			name:    "identical entries",
+			entries: []*pb.CalendarEntry{entry(10, 20, pb.BusyState_Busy), entry(10, 20, pb.BusyState_Busy)},
+			want:    "10-20 Busy",
+		},
+		{
+			name:    "busy during tentative",
+			entries: []*pb.CalendarEntry{entry(10, 40, pb.BusyState_Tentative), entry(20, 30, pb.BusyState_Busy)},
+			want:    "10-20 Tentative, 20-30 Busy, 30-40 Tentative",
+		},
+		{
+			name:    "tentative during busy",
+			entries: []*pb.CalendarEntry{entry(10, 40, pb.BusyState_Busy), entry(20, 30, pb.BusyState_Tentative)},
+			want:    "10-40 Busy",
+		},
+		{
+			name:    "tentative overlapping the end of busy",
+			entries: []*pb.CalendarEntry{entry(10, 30, pb.BusyState_Busy), entry(20, 40, pb.BusyState_Tentative)},
+			want:    "10-30 Busy, 30-40 Tentative",
+		},
+		{
+			name:    "out of office wins",
+			entries: []*pb.CalendarEntry{entry(10, 30, pb.BusyState_Busy), entry(0, 100, pb.BusyState_OutOfOffice), entry(20, 40, pb.BusyState_WorkingElsewhere)},
+			want:    "0-100 OutOfOffice",
+		},
+		{
+			name:    "free entry during busy",
+			entries: []*pb.CalendarEntry{entry(10, 30, pb.BusyState_Busy), entry(20, 40, pb.BusyState_Free)},
+			want:    "10-30 Busy",
+		},
+		{
+			name:    "gap",
+			entries: []*pb.CalendarEntry{entry(10, 20, pb.BusyState_Busy), entry(21, 30, pb.BusyState_Busy)},
+			want:    "10-20 Busy, 21-30 Busy",
+		},
+		{
+			name:    "clipped to the window",
+			entries: []*pb.CalendarEntry{entry(-10, 10, pb.BusyState_Busy), entry(90, 110, pb.BusyState_Tentative), entry(100, 120, pb.BusyState_Busy), entry(-20, 0, pb.BusyState_Busy)},
+			want:    "0-10 Busy, 90-100 Tentative",
+		},
+		{
+			name:    "empty entries",
+			entries: []*pb.CalendarEntry{entry(10, 10, pb.BusyState_Busy), entry(30, 20, pb.BusyState_Busy)},
+			want:    "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			intervals := mergeFreeBusy(tt.entries, 0, 100)
+
+			got := make([]string, 0, len(intervals))
+			for _, interval := range intervals {
+				got = append(got, fmt.Sprintf("%d-%d %s", interval.Start, interval.End, interval.Busy))
+			}
+
+			if strings.Join(got, ", ") != tt.want {
+				t.Errorf("got %s, want %s", strings.Join(got, ", "), tt.want)
+			}
+		})
+	}
+}
@@ -1,12 +1,16 @@
 package client
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
+	"maps"
 	"math"
 	"net/http"
 	"os"
+	"regexp"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
@@ -21,6 +25,7 @@ import (
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
 
 	pb "github.com/SpechtLabs/CalendarAPI/pkg/protos"
 )
@@ -30,48 +35,105 @@ type ICalClient struct {
 	cache           *pb.CalendarResponse
 	cacheExpiration time.Time
 	tracer          trace.Tracer
+	httpClient      *http.Client
 
-	statusMux    sync.RWMutex
-	CustomStatus map[string]*pb.CustomStatus // custom status is a map from calendar-name to status
+	statusStore StatusStore
+
+	caldavMux         sync.Mutex
+	caldavCollections map[string]string // discovered CalDAV collection URL by calendar
+
+	httpClientsMux sync.Mutex
+	httpClients    map[string]*http.Client // HTTP clients of calendars with custom TLS settings
+
+	statesMux sync.RWMutex
+	states    map[string]*calendarState // per-calendar state by calendar name
+
+	changedMux sync.Mutex
+	changed    chan struct{} // closed and replaced whenever the cache or a custom status changes
 }
 
 type Calendar struct {
-	Name string `mapstructure:"name"`
-	From string `mapstructure:"from"`
-	Ical string `mapstructure:"ical"`
-}
-
-var tzMapping = map[string]string{
-	"Romance Standard Time":        "Europe/Brussels",
-	"Pacific Standard Time":        "US/Pacific",
-	"W. Europe Standard Time":      "Europe/Berlin",
-	"E. Australia Standard Time":   "Australia/Brisbane",
-	"GMT Standard Time":            "Europe/Dublin",
-	"Eastern Standard Time":        "US/Eastern",
-	"Greenwich Standard Time":      "Etc/GMT",
-	"\tzone://Microsoft/Utc\"":     "UTC",
-	"Central Europe Standard Time": "Europe/Berlin",
-	"Central Standard Time":        "US/Central",
-	"Customized Time Zone":         "UTC",
-	"India Standard Time":          "Asia/Calcutta",
-	"AUS Eastern Standard Time":    "Australia/Brisbane",
-	"UTC":                          "UTC",
-	"Israel Standard Time":         "Israel",
-	"Singapore Standard Time":      "Singapore",
-}
-
-func init() {
-	gocal.SetTZMapper(func(s string) (*time.Location, error) {
-		if tzid, ok := tzMapping[s]; ok {
-			return time.LoadLocation(tzid)
-		}
-		return nil, fmt.Errorf("")
-	})
+	Name       string        `mapstructure:"name"`
+	From       string        `mapstructure:"from"`
+	Ical       string        `mapstructure:"ical"`
+	Collection string        `mapstructure:"collection"` // CalDAV collection to use if 'ical' doesn't point to one directly
+	LookBack   time.Duration `mapstructure:"lookBack"`
+	LookAhead  time.Duration `mapstructure:"lookAhead"`
+
+	// MaxStaleness limits for how long the last successfully loaded events are served while the calendar can't be refreshed
+	MaxStaleness time.Duration `mapstructure:"maxStaleness"`
+
+	Auth    CalendarAuth      `mapstructure:"auth"`
+	Headers map[string]Secret `mapstructure:"headers"`
+	TLS     CalendarTLS       `mapstructure:"tls"`
+	Privacy CalendarPrivacy   `mapstructure:"privacy"`
+
+	// Owner is the email address of the calendar's owner, whose responses decide about declined and tentative events
+	Owner string `mapstructure:"owner"`
+
+	// Timezone is the IANA time zone the calendar's days start in and its events are displayed in
+	Timezone string `mapstructure:"timezone"`
+}
+
+// TimezoneName returns the name of the calendar's time zone. Calendars without their own time
+// zone inherit the server's. It's empty if neither is configured and the local time zone is used.
+func (c Calendar) TimezoneName() string {
+	if c.Timezone != "" {
+		return c.Timezone
+	}
+
+	return viper.GetString("server.timezone")
+}
+
+// Location returns the calendar's time zone, see TimezoneName
+func (c Calendar) Location() *time.Location {
+	name := c.TimezoneName()
+	if name == "" {
+		return time.Local
+	}
+
+	loc, err := loadLocation(name)
+	if err != nil {
+		otelzap.L().WithError(err).Error("Unknown time zone, using the local time zone", zap.String("calendar", c.Name), zap.String("timezone", name))
+		return time.Local
+	}
+
+	return loc
+}
+
+// Window returns the time range [start, end) of events that are loaded for this calendar.
+// The window always covers the whole current day in the calendar's time zone and is extended
+// into the past by lookBack and into the future by lookAhead. Calendars without their own
+// values inherit the ones configured in the server section.
+func (c Calendar) Window(now time.Time) (time.Time, time.Time) {
+	now = now.In(c.Location())
+
+	lookBack := c.LookBack
+	if lookBack == 0 {
+		lookBack = viper.GetDuration("server.lookBack")
+	}
+
+	lookAhead := c.LookAhead
+	if lookAhead == 0 {
+		lookAhead = viper.GetDuration("server.lookAhead")
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	return today.Add(-lookBack), today.AddDate(0, 0, 1).Add(lookAhead)
+}
+
+// StalenessLimit returns for how long the last successfully loaded events of the calendar are
+// served while it can't be refreshed. Calendars without their own limit inherit the server's.
+func (c Calendar) StalenessLimit() time.Duration {
+	if c.MaxStaleness != 0 {
+		return c.MaxStaleness
+	}
+
+	return viper.GetDuration("server.maxStaleness")
 }
 
 func parseCalendars() []Calendar {
-	var calendars []Calendar
-	err := viper.UnmarshalKey("calendars", &calendars)
+	calendars, err := unmarshalCalendars(viper.GetViper())
 	if err != nil {
 		otelzap.L().WithError(err).Error("Failed to parse calendars")
 	}
@@ -79,12 +141,37 @@ func parseCalendars() []Calendar {
 	return calendars
 }
 
-func NewICalClient() *ICalClient {
+// ConfiguredCalendar returns the configured calendar by name. Unknown calendars get the server's
+// settings, like the window and time zone.
+func ConfiguredCalendar(name string) Calendar {
+	for _, cal := range parseCalendars() {
+		if cal.Name == name {
+			return cal
+		}
+	}
+
+	return Calendar{Name: name}
+}
+
+func unmarshalCalendars(v *viper.Viper) ([]Calendar, error) {
+	var calendars []Calendar
+	err := v.UnmarshalKey("calendars", &calendars, withSecretDecodeHook)
+	return calendars, err
+}
+
+func NewICalClient(statusStore StatusStore) *ICalClient {
+	from, to := Calendar{}.Window(time.Now())
+
 	return &ICalClient{
-		cacheExpiration: time.Now(),
-		cache:           &pb.CalendarResponse{LastUpdated: time.Now().Unix()},
-		CustomStatus:    make(map[string]*pb.CustomStatus),
-		tracer:          otel.GetTracerProvider().Tracer("github.com/SpechtLabs/CalendarAPI/pkg/client"),
+		cacheExpiration:   time.Now(),
+		cache:             &pb.CalendarResponse{LastUpdated: time.Now().Unix(), From: from.Unix(), To: to.Unix()},
+		statusStore:       statusStore,
+		caldavCollections: make(map[string]string),
+		httpClients:       make(map[string]*http.Client),
+		states:            make(map[string]*calendarState),
+		changed:           make(chan struct{}),
+		tracer:            otel.GetTracerProvider().Tracer("github.com/SpechtLabs/CalendarAPI/pkg/client"),
+		httpClient:        http.DefaultClient,
 	}
 }
 
@@ -92,51 +179,142 @@ func (e *ICalClient) FetchEvents(ctx context.Context) {
 	ctx, span := e.tracer.Start(ctx, "ICalClient.FetchEvents")
 	defer span.End()
 
+	now := time.Now()
 	response := &pb.CalendarResponse{
-		LastUpdated: time.Now().Unix(),
+		LastUpdated: now.Unix(),
 		Entries:     make([]*pb.CalendarEntry, 0),
+		Timezone:    Calendar{}.TimezoneName(),
 	}
 
 	calendars := parseCalendars()
 	rules := parseRules()
+	e.pruneCalendarStates(calendars)
+	e.pruneExpiredStatuses(ctx, now)
+
+	// The cached window is the union of the server window and all calendar windows
+	cacheStart, cacheEnd := Calendar{}.Window(now)
+	for _, cal := range calendars {
+		start, end := cal.Window(now)
+		if start.Before(cacheStart) {
+			cacheStart = start
+		}
+		if end.After(cacheEnd) {
+			cacheEnd = end
+		}
+	}
+	response.From, response.To = cacheStart.Unix(), cacheEnd.Unix()
 
 	var wg sync.WaitGroup
 	var eventsMux sync.Mutex
 
 	for _, cal := range calendars {
-		name := cal.Name
-		from := cal.From
-		url := cal.Ical
-
 		wg.Add(1)
 
 		go func() {
 			defer wg.Done()
 
-			start := time.Now()
-			events, err := e.loadEvents(ctx, name, from, url, rules)
-			stop := time.Now()
-			if err != nil {
-				otelzap.L().WithError(err).Ctx(ctx).Error("Unable to load events", zap.String("calendar", name), zap.String("from", from), zap.String("url", url))
-			}
+			events, info := e.refreshCalendar(ctx, cal, now, rules)
 
 			eventsMux.Lock()
 			response.LastUpdated = time.Now().Unix()
 			response.Entries = append(response.Entries, events...)
+			response.Calendars = append(response.Calendars, info)
 			eventsMux.Unlock()
-
-			otelzap.L().Ctx(ctx).Info("Refreshed calendar", zap.String("name", name), zap.Duration("duration", stop.Sub(start)))
 		}()
 	}
 
 	wg.Wait()
 
+	sort.Slice(response.Calendars, func(i int, j int) bool {
+		return response.Calendars[i].Name < response.Calendars[j].Name
+	})
+
 	// Sort Events by start-date (makes our live easier down the line)
-	sort.Slice(response.Entries, func(i int, j int) bool {
-		leftStart := time.Unix(response.Entries[i].Start, 0)
-		rightStart := time.Unix(response.Entries[j].Start, 0)
-		leftEnd := time.Unix(response.Entries[i].End, 0)
-		rightEnd := time.Unix(response.Entries[j].End, 0)
+	sortEntries(response.Entries)
+
+	e.cacheMux.Lock()
+	e.cache = response
+	e.cacheMux.Unlock()
+
+	e.notifyChanged()
+}
+
+// RefreshCalendar refreshes the given calendar ("all" for every calendar) right away and
+// returns the outcome of the refresh
+func (e *ICalClient) RefreshCalendar(ctx context.Context, calendar string) (*pb.RefreshCalendarResponse, humane.Error) {
+	ctx, span := e.tracer.Start(ctx, "ICalClient.RefreshCalendar")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("calendar", calendar))
+
+	if calendar == "all" {
+		e.FetchEvents(ctx)
+
+		e.cacheMux.RLock()
+		defer e.cacheMux.RUnlock()
+
+		return &pb.RefreshCalendarResponse{CalendarName: calendar, Calendars: e.cache.Calendars}, nil
+	}
+
+	var cal *Calendar
+	calendars := parseCalendars()
+	for i := range calendars {
+		if calendars[i].Name == calendar {
+			cal = &calendars[i]
+			break
+		}
+	}
+
+	if cal == nil {
+		return nil, humane.New(fmt.Sprintf("calendar %s is not configured", calendar), "use 'all' or the name of one of the configured calendars")
+	}
+
+	events, info := e.refreshCalendar(ctx, *cal, time.Now(), parseRules())
+
+	// Replace the calendar's events and info in the cache, leaving all other calendars untouched
+	e.cacheMux.Lock()
+	defer e.cacheMux.Unlock()
+
+	response := &pb.CalendarResponse{
+		LastUpdated: time.Now().Unix(),
+		From:        e.cache.From,
+		To:          e.cache.To,
+		Timezone:    e.cache.Timezone,
+		Entries:     make([]*pb.CalendarEntry, 0, len(e.cache.Entries)+len(events)),
+		Calendars:   make([]*pb.CalendarInfo, 0, len(e.cache.Calendars)+1),
+	}
+
+	for _, entry := range e.cache.Entries {
+		if entry.CalendarName != calendar {
+			response.Entries = append(response.Entries, entry)
+		}
+	}
+	response.Entries = append(response.Entries, events...)
+	sortEntries(response.Entries)
+
+	for _, other := range e.cache.Calendars {
+		if other.Name != calendar {
+			response.Calendars = append(response.Calendars, other)
+		}
+	}
+	response.Calendars = append(response.Calendars, info)
+	sort.Slice(response.Calendars, func(i int, j int) bool {
+		return response.Calendars[i].Name < response.Calendars[j].Name
+	})
+
+	e.cache = response
+	e.notifyChanged()
+
+	return &pb.RefreshCalendarResponse{CalendarName: calendar, Calendars: []*pb.CalendarInfo{info}}, nil
+}
+
+// sortEntries sorts the entries by their start, entries starting at the same time by their end
+func sortEntries(entries []*pb.CalendarEntry) {
+	sort.Slice(entries, func(i int, j int) bool {
+		leftStart := time.Unix(entries[i].Start, 0)
+		rightStart := time.Unix(entries[j].Start, 0)
+		leftEnd := time.Unix(entries[i].End, 0)
+		rightEnd := time.Unix(entries[j].End, 0)
 
 		if leftStart.Equal(rightStart) {
 			return leftEnd.Before(rightEnd)
@@ -144,13 +322,96 @@ func (e *ICalClient) FetchEvents(ctx context.Context) {
 
 		return leftStart.Before(rightStart)
 	})
+}
 
-	e.cacheMux.Lock()
-	e.cache = response
-	e.cacheMux.Unlock()
+// refreshCalendar loads the events of the calendar for its window at now. If that fails, the last
+// known good events are returned for up to the calendar's staleness limit.
+func (e *ICalClient) refreshCalendar(ctx context.Context, cal Calendar, now time.Time, rules []Rule) ([]*pb.CalendarEntry, *pb.CalendarInfo) {
+	windowStart, windowEnd := cal.Window(now)
+
+	start := time.Now()
+	events, err := e.loadEvents(ctx, cal, windowStart, windowEnd, rules)
+	stop := time.Now()
+
+	info := &pb.CalendarInfo{
+		Name:        cal.Name,
+		From:        cal.From,
+		LastRefresh: stop.Unix(),
+		Duration:    stop.Sub(start).Milliseconds(),
+		Timezone:    cal.TimezoneName(),
+	}
+
+	if err != nil {
+		otelzap.L().WithError(err).Ctx(ctx).Error("Unable to load events", zap.String("calendar", cal.Name), zap.String("from", cal.From), zap.String("url", cal.Ical))
+		info.LastError = errorChain(err)
+
+		// Rather show slightly outdated events than none at all
+		if state := e.getCalendarState(cal); state != nil && !state.lastSuccess.IsZero() {
+			info.LastSuccess = state.lastSuccess.Unix()
+
+			if staleness := stop.Sub(state.lastSuccess); staleness <= cal.StalenessLimit() {
+				otelzap.L().Ctx(ctx).Warn("Serving last known good events", zap.String("calendar", cal.Name), zap.Duration("staleness", staleness))
+				events = eventsInWindow(state.entries, windowStart, windowEnd)
+				info.Stale = true
+			}
+		}
+	} else {
+		e.updateCalendarState(cal, func(state *calendarState) {
+			state.entries = events
+			state.lastSuccess = stop
+		})
+		info.LastSuccess = stop.Unix()
+	}
+
+	info.EventCount = int32(len(events))
+	otelzap.L().Ctx(ctx).Info("Refreshed calendar", zap.String("name", cal.Name), zap.Duration("duration", stop.Sub(start)))
+
+	return events, info
+}
+
+// errorChain joins the messages of err and its causes, e.g. "failed to load: connection refused".
+// Plain Go errors already contain the messages of their causes, so we stop at the first one.
+func errorChain(err humane.Error) string {
+	messages := []string{err.Error()}
+	for cause := err.Cause(); cause != nil; {
+		messages = append(messages, cause.Error())
+
+		herr, ok := cause.(humane.Error)
+		if !ok {
+			break
+		}
+		cause = herr.Cause()
+	}
+
+	return strings.Join(messages, ": ")
+}
+
+// ListCalendars returns the state of every calendar as of the last refresh
+func (e *ICalClient) ListCalendars(ctx context.Context) *pb.ListCalendarsResponse {
+	ctx, span := e.tracer.Start(ctx, "ICalClient.ListCalendars")
+	defer span.End()
+
+	if e.cache == nil {
+		otelzap.L().Ctx(ctx).Info("Experiencing cold. Fetching events now!")
+		e.FetchEvents(ctx)
+	}
+
+	e.cacheMux.RLock()
+	defer e.cacheMux.RUnlock()
+
+	response := &pb.ListCalendarsResponse{
+		Calendars: make([]*pb.CalendarInfo, 0, len(e.cache.Calendars)),
+	}
+	response.Calendars = append(response.Calendars, e.cache.Calendars...)
+
+	return response
 }
 
-func (e *ICalClient) GetEvents(ctx context.Context) *pb.CalendarResponse {
+// GetEvents returns the cached events of the given calendar ("all" for every calendar) that
+// overlap the time range [from, to). A zero from or to defaults to the respective boundary of
+// the cached window. Ranges reaching outside the cached window are rejected, since events
+// outside of it have never been loaded.
+func (e *ICalClient) GetEvents(ctx context.Context, calendar string, from int64, to int64) (*pb.CalendarResponse, humane.Error) {
 	ctx, span := e.tracer.Start(ctx, "ICalClient.GetEvents")
 	defer span.End()
 
@@ -161,7 +422,115 @@ func (e *ICalClient) GetEvents(ctx context.Context) *pb.CalendarResponse {
 
 	e.cacheMux.RLock()
 	defer e.cacheMux.RUnlock()
-	return e.cache
+
+	return e.cachedEvents(ctx, calendar, func(name string) bool { return calendar == "all" || name == calendar }, from, to)
+}
+
+// GetMergedEvents is GetEvents for several calendars (all calendars if empty or "all"), whose
+// events are merged into a single response named after the calendars. Calendars that aren't
+// configured are rejected.
+func (e *ICalClient) GetMergedEvents(ctx context.Context, calendars []string, from int64, to int64) (*pb.CalendarResponse, humane.Error) {
+	ctx, span := e.tracer.Start(ctx, "ICalClient.GetMergedEvents")
+	defer span.End()
+
+	span.SetAttributes(attribute.StringSlice("calendars", calendars))
+
+	if e.cache == nil {
+		otelzap.L().Ctx(ctx).Info("Experiencing cold. Fetching events now!")
+		e.FetchEvents(ctx)
+	}
+
+	e.cacheMux.RLock()
+	defer e.cacheMux.RUnlock()
+
+	all := len(calendars) == 0 || slices.Contains(calendars, "all") || slices.Contains(calendars, "*")
+
+	calendars, _, err := e.cachedEntries(calendars)
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.Join(calendars, ",")
+	if all {
+		name = "all"
+	}
+
+	return e.cachedEvents(ctx, name, func(name string) bool { return slices.Contains(calendars, name) }, from, to)
+}
+
+// cachedEvents returns the cached events of the calendars included selects in a response named
+// calendar. The caller must hold the cacheMux.
+func (e *ICalClient) cachedEvents(ctx context.Context, calendar string, included func(name string) bool, from int64, to int64) (*pb.CalendarResponse, humane.Error) {
+	from, to, err := e.cachedRange(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &pb.CalendarResponse{
+		LastUpdated:  e.cache.LastUpdated,
+		CalendarName: calendar,
+		From:         from,
+		To:           to,
+		Entries:      make([]*pb.CalendarEntry, 0),
+		Calendars:    make([]*pb.CalendarInfo, 0),
+		Timezone:     e.cache.Timezone,
+	}
+
+	for _, info := range e.cache.Calendars {
+		if included(info.Name) {
+			response.Calendars = append(response.Calendars, info)
+		}
+
+		if info.Name == calendar {
+			response.Timezone = info.Timezone
+		}
+	}
+
+	mask := privacyMasker(ctx)
+	for _, entry := range e.cache.Entries {
+		if !included(entry.CalendarName) {
+			continue
+		}
+
+		if entry.Start >= to || entry.End <= from {
+			continue
+		}
+
+		if masked := mask(entry); masked != nil {
+			response.Entries = append(response.Entries, masked)
+		}
+	}
+
+	return response, nil
+}
+
+// cachedRange defaults a zero from or to to the respective boundary of the cached window and
+// makes sure the time range [from, to) lies within it. The caller must hold the cacheMux.
+func (e *ICalClient) cachedRange(from int64, to int64) (int64, int64, humane.Error) {
+	if from == 0 {
+		from = e.cache.From
+	}
+
+	if to == 0 {
+		to = e.cache.To
+	}
+
+	if from >= to {
+		return 0, 0, humane.New(fmt.Sprintf("invalid time range [%s, %s)", time.Unix(from, 0).Format(time.RFC3339), time.Unix(to, 0).Format(time.RFC3339)),
+			"make sure 'from' is before 'to'",
+		)
+	}
+
+	if from < e.cache.From || to > e.cache.To {
+		return 0, 0, humane.New(fmt.Sprintf("time range [%s, %s) is outside of the cached window [%s, %s)",
+			time.Unix(from, 0).Format(time.RFC3339), time.Unix(to, 0).Format(time.RFC3339),
+			time.Unix(e.cache.From, 0).Format(time.RFC3339), time.Unix(e.cache.To, 0).Format(time.RFC3339)),
+			"request a time range inside the cached window",
+			"increase 'lookBack' or 'lookAhead' in the server or calendar configuration",
+		)
+	}
+
+	return from, to, nil
 }
 
 func (e *ICalClient) GetCurrentEvent(ctx context.Context, calendar string) *pb.CalendarEntry {
@@ -177,16 +546,21 @@ func (e *ICalClient) GetCurrentEvent(ctx context.Context, calendar string) *pb.C
 	defer e.cacheMux.RUnlock()
 
 	var possibleCurrentEvents []*pb.CalendarEntry
+	mask := privacyMasker(ctx)
 
-	// Find all events happening right now
+	// Find all events happening right now the caller may see
 	now := time.Now().Unix()
 	for _, entry := range e.cache.Entries {
 		if calendar != "all" && entry.CalendarName != calendar {
 			continue
 		}
 
-		if entry.Start < now && entry.End > now {
-			possibleCurrentEvents = append(possibleCurrentEvents, entry)
+		if entry.Start >= now || entry.End <= now {
+			continue
+		}
+
+		if masked := mask(entry); masked != nil {
+			possibleCurrentEvents = append(possibleCurrentEvents, masked)
 		}
 	}
 
@@ -216,38 +590,116 @@ func (e *ICalClient) GetCurrentEvent(ctx context.Context, calendar string) *pb.C
 	return closest
 }
 
+// GetCustomStatus returns the custom status of the calendar, or an empty status if there is
+// none or it isn't valid right now. Expired statuses are removed.
 func (e *ICalClient) GetCustomStatus(ctx context.Context, req *pb.GetCustomStatusRequest) *pb.CustomStatus {
-	_, span := e.tracer.Start(ctx, "ICalClient.GetCustomStatus")
+	ctx, span := e.tracer.Start(ctx, "ICalClient.GetCustomStatus")
 	defer span.End()
 
-	e.statusMux.RLock()
-	defer e.statusMux.RUnlock()
+	val, ok := e.statusStore.Get(req.CalendarName)
+	if !ok {
+		return &pb.CustomStatus{}
+	}
+
+	now := time.Now()
+	if statusExpired(val, now) {
+		if err := e.statusStore.Delete(req.CalendarName); err != nil {
+			otelzap.L().WithError(err).Ctx(ctx).Error("Unable to remove expired custom status", zap.String("calendar", req.CalendarName))
+		}
+		return &pb.CustomStatus{}
+	}
+
+	if val.ValidFrom != 0 && now.Unix() < val.ValidFrom {
+		return &pb.CustomStatus{}
+	}
+
+	return val
+}
 
-	if val, ok := e.CustomStatus[req.CalendarName]; ok {
-		return val
+// pruneExpiredStatuses removes the custom statuses whose valid_until has passed, so they don't
+// linger in the store of calendars nobody asks for
+func (e *ICalClient) pruneExpiredStatuses(ctx context.Context, now time.Time) {
+	for calendar, status := range e.statusStore.All() {
+		if !statusExpired(status, now) {
+			continue
+		}
+
+		if err := e.statusStore.Delete(calendar); err != nil {
+			otelzap.L().WithError(err).Ctx(ctx).Error("Unable to remove expired custom status", zap.String("calendar", calendar))
+		}
 	}
+}
 
-	return &pb.CustomStatus{}
+// statusExpired reports whether the status' valid_until has passed
+func statusExpired(status *pb.CustomStatus, now time.Time) bool {
+	return status.ValidUntil != 0 && now.Unix() >= status.ValidUntil
 }
 
-func (e *ICalClient) SetCustomStatus(ctx context.Context, req *pb.SetCustomStatusRequest) {
+// ValidateCustomStatus checks that the status can be shown at some point after now
+func ValidateCustomStatus(status *pb.CustomStatus, now time.Time) humane.Error {
+	if status == nil {
+		return nil
+	}
+
+	if status.ValidFrom != 0 && status.ValidUntil != 0 && status.ValidUntil <= status.ValidFrom {
+		return humane.New("custom status ends before it starts", "make sure 'valid_until' is after 'valid_from'")
+	}
+
+	if statusExpired(status, now) {
+		return humane.New("custom status is already expired", "make sure 'valid_until' is in the future")
+	}
+
+	return nil
+}
+
+// SetCustomStatus sets the custom status of the calendar. Setting an empty status clears it.
+func (e *ICalClient) SetCustomStatus(ctx context.Context, req *pb.SetCustomStatusRequest) humane.Error {
 	_, span := e.tracer.Start(ctx, "ICalClient.SetCustomStatus")
 	defer span.End()
 
-	e.statusMux.Lock()
-	defer e.statusMux.Unlock()
+	if err := ValidateCustomStatus(req.Status, time.Now()); err != nil {
+		return err
+	}
+
+	var err humane.Error
+	if req.Status == nil || proto.Equal(req.Status, &pb.CustomStatus{}) {
+		err = e.statusStore.Delete(req.CalendarName)
+	} else {
+		err = e.statusStore.Set(req.CalendarName, req.Status)
+	}
 
-	e.CustomStatus[req.CalendarName] = req.Status
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return humane.Wrap(err, fmt.Sprintf("unable to store custom status of calendar %s", req.CalendarName))
+	}
+
+	e.notifyChanged()
+	return nil
 }
 
-func safeIcalParse(ical io.ReadCloser) (events []gocal.Event, err humane.Error) {
-	// Filter to TODAY only
-	today, _ := time.Parse(time.DateOnly, time.Now().Format(time.DateOnly))
-	eod := today.Add(23*time.Hour + 59*time.Minute + 59*time.Second)
+// transpAttribute is the custom attribute TRANSP properties are renamed to, gocal drops TRANSP otherwise
+const transpAttribute = "X-CALENDARAPI-TRANSP"
+
+// transpProperty matches TRANSP properties at the start of a (unfolded) content line
+var transpProperty = regexp.MustCompile(`(?m)^TRANSP([;:])`)
 
-	cal := gocal.NewParser(ical)
-	start, end := today, eod
-	cal.Start, cal.End = &start, &end
+// safeIcalParse parses all events of the iCal file of the calendar that overlap the time range [start, end).
+// All-day events and times without a time zone are interpreted in the calendar's time zone.
+func safeIcalParse(calendar Calendar, ical io.Reader, start time.Time, end time.Time) (events []gocal.Event, err humane.Error) {
+	// gocal only keeps events strictly overlapping its bounds, which would drop events starting
+	// exactly at start. So we widen the bounds by a second and filter precisely afterwards.
+	parseStart, parseEnd := start.Add(-time.Second), end.Add(time.Second)
+
+	raw, readErr := io.ReadAll(ical)
+	if readErr != nil {
+		return nil, humane.Wrap(readErr, "unable to read iCal data")
+	}
+
+	// gocal only keeps custom attributes starting with X-
+	raw = transpProperty.ReplaceAll(raw, []byte(transpAttribute+"$1"))
+	raw = tagFloatingTimes(raw)
+	raw = protectEscapes(raw)
 
 	// Protect against panics in gocal.Parse
 	defer func() {
@@ -259,79 +711,120 @@ func safeIcalParse(ical io.ReadCloser) (events []gocal.Event, err humane.Error)
 		}
 	}()
 
-	if err := cal.Parse(); err != nil {
-		return nil, humane.Wrap(err, "unable to parse iCal file", "ensure the iCal file is valid and follows the iCal spec")
+	var cal *gocal.Gocal
+	var parseErr error
+	withTimezones(calendar, raw, func(tagged []byte) {
+		cal = gocal.NewParser(bytes.NewReader(tagged))
+		cal.Start, cal.End = &parseStart, &parseEnd
+		cal.AllDayEventsTZ = calendar.Location()
+		parseErr = cal.Parse()
+	})
+	if parseErr != nil {
+		return nil, humane.Wrap(parseErr, "unable to parse iCal file", "ensure the iCal file is valid and follows the iCal spec")
+	}
+
+	events = make([]gocal.Event, 0, len(cal.Events))
+	for _, evnt := range cal.Events {
+		if evnt.Start.Before(end) && evnt.End.After(start) {
+			events = append(events, evnt)
+		}
 	}
 
-	return cal.Events, nil
+	return events, nil
 }
 
-func (e *ICalClient) loadEvents(ctx context.Context, calName string, from string, url string, rules []Rule) ([]*pb.CalendarEntry, humane.Error) {
+func (e *ICalClient) loadEvents(ctx context.Context, cal Calendar, windowStart time.Time, windowEnd time.Time, rules []Rule) ([]*pb.CalendarEntry, humane.Error) {
 	ctx, span := e.tracer.Start(ctx, "ICalClient.loadEvents")
 	defer span.End()
 
 	span.SetAttributes(
-		attribute.String("calendar.name", calName),
-		attribute.String("calendar.from", from),
-		attribute.String("calendar.url", url),
+		attribute.String("calendar.name", cal.Name),
+		attribute.String("calendar.from", cal.From),
+		attribute.String("calendar.url", cal.Ical),
+		attribute.String("calendar.window_start", windowStart.Format(time.RFC3339)),
+		attribute.String("calendar.window_end", windowEnd.Format(time.RFC3339)),
 	)
 
-	ical, err := e.getIcal(ctx, from, url)
+	ical, err := e.getIcal(ctx, cal, windowStart, windowEnd)
 	if ical == nil || err != nil {
 		return nil, humane.Wrap(err, "failed to load iCal calendar file")
 	}
 
-	defer func(ical io.ReadCloser) {
-		err := ical.Close()
-		if err != nil {
-			otelzap.L().WithError(err).Ctx(ctx).Error("Failed to close iCal file")
-		}
-	}(ical)
-
-	calEvents, err := safeIcalParse(ical)
+	calEvents, err := e.parseIcal(ctx, cal, ical, windowStart, windowEnd)
 	if err != nil {
 		return nil, humane.Wrap(err, "failed to parse iCal calendar file")
 	}
 
 	events := make([]*pb.CalendarEntry, 0)
 	for _, evnt := range calEvents {
-		event := NewCalendarEntryFromGocalEvent(calName, evnt)
-		if event == nil {
-			continue
+		if event, _ := evaluateEvent(cal, evnt, rules); event != nil {
+			events = append(events, event)
 		}
+	}
 
-		// let's evaluate our rules
-		for _, rule := range rules {
-			// if a rule is sucessfully evaluated
-			if ok, skip := rule.Evaluate(event); ok {
-				// if this is a skip rule, don't process any other rules for this
-				// event and don't add it
-				if skip {
-					break
-				}
-
-				events = append(events, event)
-
-				// since we found the first rule that matches, no need to
-				// process any more rules
-				break
-			}
-		}
+	return events, nil
+}
+
+// parseIcal parses the iCal data of the calendar. If neither the data, the window nor the time
+// zones changed since the last refresh, the previously parsed events are reused.
+func (e *ICalClient) parseIcal(ctx context.Context, cal Calendar, ical *icalData, windowStart time.Time, windowEnd time.Time) ([]gocal.Event, humane.Error) {
+	_, span := e.tracer.Start(ctx, "ICalClient.parseIcal")
+	defer span.End()
+
+	timezones := timezoneOverrides()
+	location := cal.TimezoneName()
+
+	state := e.getCalendarState(cal)
+	if state != nil && state.windowStart.Equal(windowStart) && state.windowEnd.Equal(windowEnd) &&
+		state.location == location && maps.Equal(state.timezones, timezones) && bytes.Equal(state.ical.raw, ical.raw) {
+		span.SetAttributes(attribute.Bool("calendar.cache_hit", true))
+
+		// servers may answer an unchanged body with new validators, which the next request should send
+		e.updateCalendarState(cal, func(state *calendarState) {
+			state.ical.etag, state.ical.lastModified = ical.etag, ical.lastModified
+		})
+
+		return state.events, nil
 	}
 
+	span.SetAttributes(attribute.Bool("calendar.cache_hit", false))
+
+	events, err := safeIcalParse(cal, bytes.NewReader(ical.raw), windowStart, windowEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	e.updateCalendarState(cal, func(state *calendarState) {
+		state.ical = *ical
+		state.windowStart, state.windowEnd = windowStart, windowEnd
+		state.location, state.timezones = location, timezones
+		state.events = events
+	})
+
 	return events, nil
 }
 
-func NewCalendarEntryFromGocalEvent(calName string, e gocal.Event) *pb.CalendarEntry {
-	if strings.Contains(e.Summary, "Canceled") {
+// NewCalendarEntryFromGocalEvent converts the event of the calendar, or returns nil if the event
+// was cancelled or declined by the calendar's owner
+func NewCalendarEntryFromGocalEvent(cal Calendar, e gocal.Event) *pb.CalendarEntry {
+	if strings.EqualFold(e.Status, "CANCELLED") {
 		return nil
 	}
 
-	if strings.Contains(e.Summary, "Declined") {
+	partstat := ownerPartstat(cal.Owner, e)
+	if partstat == "DECLINED" {
 		return nil
 	}
 
-	busy := pb.BusyState_Free
+	// events block time unless they are transparent
+	busy := pb.BusyState_Busy
+	if strings.EqualFold(e.CustomAttributes[transpAttribute], "TRANSPARENT") {
+		busy = pb.BusyState_Free
+	} else if strings.EqualFold(e.Status, "TENTATIVE") || partstat == "TENTATIVE" {
+		busy = pb.BusyState_Tentative
+	}
+
+	// Exchange knows best, it already takes the owner's response into account
 	if val, ok := e.CustomAttributes["X-MICROSOFT-CDO-BUSYSTATUS"]; ok {
 		switch val {
 		case "BUSY":
@@ -350,44 +843,138 @@ func NewCalendarEntryFromGocalEvent(calName string, e gocal.Event) *pb.CalendarE
 		}
 	}
 
-	allDay := false
+	allDay := strings.EqualFold(e.RawStart.Params["VALUE"], "DATE")
 	if val, ok := e.CustomAttributes["X-MICROSOFT-CDO-ALLDAYEVENT"]; ok {
 		allDay = val == "TRUE"
 	}
 
-	start := e.Start.In(time.Local)
-	end := e.End.In(time.Local)
+	// the organizer doesn't want the details of these events to be shared
+	private := e.Class == "PRIVATE" || e.Class == "CONFIDENTIAL"
+
+	var organizer *pb.Person
+	if e.Organizer != nil {
+		organizer = &pb.Person{Name: icalRaw.Replace(e.Organizer.Cn), Email: emailAddress(icalRaw.Replace(e.Organizer.Value))}
+	}
+
+	attendees := make([]*pb.Attendee, 0, len(e.Attendees))
+	for _, attendee := range e.Attendees {
+		attendees = append(attendees, &pb.Attendee{
+			Name:   icalRaw.Replace(attendee.Cn),
+			Email:  emailAddress(icalRaw.Replace(attendee.Value)),
+			Status: strings.ToUpper(attendee.Status),
+		})
+	}
+
+	categories := make([]string, 0, len(e.Categories))
+	for _, category := range e.Categories {
+		if category = strings.TrimSpace(icalText.Replace(category)); category != "" {
+			categories = append(categories, category)
+		}
+	}
 
 	return &pb.CalendarEntry{
-		Title:        e.Summary,
-		Start:        start.Unix(),
-		End:          end.Unix(),
+		Title:        icalText.Replace(e.Summary),
+		Start:        e.Start.Unix(),
+		End:          e.End.Unix(),
 		AllDay:       allDay,
 		Busy:         busy,
-		CalendarName: calName,
+		CalendarName: cal.Name,
+		Private:      private,
+		Location:     icalText.Replace(e.Location),
+		Organizer:    organizer,
+		Attendees:    attendees,
+		Description:  icalText.Replace(e.Description),
+		Uid:          icalText.Replace(e.Uid),
+		Url:          icalRaw.Replace(e.URL),
+		Categories:   categories,
+		Timezone:     cal.TimezoneName(),
+	}
+}
+
+// escapedNewline and escapedBackslash replace escaped newlines and backslashes before parsing.
+// gocal unescapes backslashes before commas and semicolons, so `\\,` ends up as a plain comma, and
+// it keeps escaped newlines, which can't be told apart from a backslash followed by n afterwards.
+const (
+	escapedNewline   = "\uE000"
+	escapedBackslash = "\uE001"
+)
+
+// textEscape matches escaped backslashes and newlines, even if the line is folded in between
+var textEscape = regexp.MustCompile(`\\(?:\r?\n[ \t])?[\\nN]`)
+
+// protectEscapes replaces the escaped newlines and backslashes in the iCal data, see escapedNewline
+func protectEscapes(raw []byte) []byte {
+	return textEscape.ReplaceAllFunc(raw, func(escape []byte) []byte {
+		if escape[len(escape)-1] == '\\' {
+			return []byte(escapedBackslash)
+		}
+
+		return []byte(escapedNewline)
+	})
+}
+
+// icalText restores the newlines and backslashes in TEXT values protectEscapes replaced
+var icalText = strings.NewReplacer(escapedNewline, "\n", escapedBackslash, `\`)
+
+// icalRaw restores the characters protectEscapes replaced in values that aren't TEXT, like URIs
+var icalRaw = strings.NewReplacer(escapedNewline, `\n`, escapedBackslash, `\\`)
+
+// emailAddress returns the email address of a CAL-ADDRESS like mailto:alice@example.com
+func emailAddress(calAddress string) string {
+	if len(calAddress) >= len("mailto:") && strings.EqualFold(calAddress[:len("mailto:")], "mailto:") {
+		return calAddress[len("mailto:"):]
 	}
+
+	return calAddress
+}
+
+// ownerPartstat returns the participation status (PARTSTAT) of the calendar's owner in the
+// event, or "" if the owner isn't configured or not among the attendees
+func ownerPartstat(owner string, e gocal.Event) string {
+	if owner == "" {
+		return ""
+	}
+
+	for _, attendee := range e.Attendees {
+		if strings.EqualFold(emailAddress(attendee.Value), emailAddress(owner)) {
+			return strings.ToUpper(attendee.Status)
+		}
+	}
+
+	return ""
 }
 
-func (e *ICalClient) getIcal(ctx context.Context, from string, url string) (io.ReadCloser, humane.Error) {
-	switch from {
+func (e *ICalClient) getIcal(ctx context.Context, cal Calendar, windowStart time.Time, windowEnd time.Time) (*icalData, humane.Error) {
+	switch cal.From {
 	case "file":
-		return e.getIcalFromFile(url)
+		return e.getIcalFromFile(cal.Ical)
 	case "url":
-		return e.getIcalFromURL(ctx, url)
+		return e.getIcalFromURL(ctx, cal)
+	case "caldav":
+		return e.getIcalFromCalDAV(ctx, cal, windowStart, windowEnd)
 	default:
-		return nil, humane.New("unsupported 'from' type", "The only supported values for 'from' are 'file' or 'url'")
+		return nil, humane.New("unsupported 'from' type", "The only supported values for 'from' are 'file', 'url' or 'caldav'")
 	}
 }
 
-func (e *ICalClient) getIcalFromFile(path string) (io.ReadCloser, humane.Error) {
-	file, err := os.Open(path)
-	return file, humane.Wrap(err, "unbable to read iCal File", "check if file path exists and is accessible")
+func (e *ICalClient) getIcalFromFile(path string) (*icalData, humane.Error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, humane.Wrap(err, "unbable to read iCal File", "check if file path exists and is accessible")
+	}
+
+	return &icalData{raw: raw}, nil
 }
 
-func (e *ICalClient) getIcalFromURL(ctx context.Context, url string) (io.ReadCloser, humane.Error) {
+// getIcalFromURL downloads the iCal file. If the server announced an ETag or Last-Modified date
+// on the previous download, the request is made conditional and the raw cache is reused if the
+// server reports that the file didn't change.
+func (e *ICalClient) getIcalFromURL(ctx context.Context, cal Calendar) (*icalData, humane.Error) {
 	ctx, span := e.tracer.Start(ctx, "ICalClient.getIcalFromURL")
 	defer span.End()
 
+	url := cal.Ical
+
 	span.SetAttributes(
 		attribute.String("http.method", http.MethodGet),
 	)
@@ -398,9 +985,31 @@ func (e *ICalClient) getIcalFromURL(ctx context.Context, url string) (io.ReadClo
 		span.SetStatus(codes.Error, err.Error())
 		return nil, humane.Wrap(err, fmt.Sprintf("failed creating request for %s", url), "verify if URL is valid and well-formed")
 	}
-	req.Header.Set("User-Agent", "Mozilla/5.0 (X11; Linux x86_64; rv:60.0) Gecko/20100101 Firefox/81.0")
 
-	client := http.DefaultClient
+	if err := authorizeRequest(req, cal); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, humane.Wrap(err, fmt.Sprintf("failed to authorize request for %s", url), "verify the calendar's 'auth' and 'headers' settings")
+	}
+
+	state := e.getCalendarState(cal)
+	if state != nil && len(state.ical.raw) > 0 {
+		if state.ical.etag != "" {
+			req.Header.Set("If-None-Match", state.ical.etag)
+		}
+
+		if state.ical.lastModified != "" {
+			req.Header.Set("If-Modified-Since", state.ical.lastModified)
+		}
+	}
+
+	client, herr := e.calendarHTTPClient(cal)
+	if herr != nil {
+		span.RecordError(herr)
+		span.SetStatus(codes.Error, herr.Error())
+		return nil, humane.Wrap(herr, fmt.Sprintf("failed to configure TLS for %s", url), "verify the calendar's 'tls' settings")
+	}
+
 	resp, err := client.Do(req)
 	if err != nil {
 		span.RecordError(err)
@@ -408,16 +1017,36 @@ func (e *ICalClient) getIcalFromURL(ctx context.Context, url string) (io.ReadClo
 		return nil, humane.Wrap(err, fmt.Sprintf("failed making request to %s", url), "verify if URL exists and is accessible")
 	}
 
-	// Add error handling for non-2xx status codes
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		// Close the response body to avoid leaking resources
+	defer func() {
 		_ = resp.Body.Close()
+	}()
+
+	span.SetAttributes(attribute.Int("http.status_code", resp.StatusCode))
 
+	// The file didn't change since we downloaded it the last time
+	if resp.StatusCode == http.StatusNotModified && state != nil {
+		ical := state.ical
+		return &ical, nil
+	}
+
+	// Add error handling for non-2xx status codes
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 		statusErr := fmt.Errorf("unexpected HTTP status: %s", resp.Status)
 		span.SetStatus(codes.Error, "received non-2xx status code")
 		span.RecordError(statusErr)
 		return nil, humane.Wrap(statusErr, "server returned an error")
 	}
 
-	return resp.Body, nil
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, humane.Wrap(err, fmt.Sprintf("failed reading response from %s", url), "verify if URL exists and is accessible")
+	}
+
+	return &icalData{
+		raw:          raw,
+		etag:         resp.Header.Get("ETag"),
+		lastModified: resp.Header.Get("Last-Modified"),
+	}, nil
 }
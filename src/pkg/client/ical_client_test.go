@@ -0,0 +1,478 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+
+	pb "github.com/SpechtLabs/CalendarAPI/pkg/protos"
+)
+
+func TestNewCalendarEntryFromGocalEventText(t *testing.T) {
+	ical := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"BEGIN:VEVENT",
+		"UID:text@example.com",
+		"DTSTAMP:20250401T000000Z",
+		"DTSTART:20250401T090000Z",
+		"DTEND:20250401T100000Z",
+		`SUMMARY:Path C:\\new`,
+		`LOCATION:Room 1\, floor 2\; east wing`,
+		`DESCRIPTION:First line\nSecond line\Nthird \\\n and a folded esc`,
+		` \nape`,
+		`CATEGORIES:Back\\slash,Trailing\\,Plain`,
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+
+	start := time.Date(2025, time.April, 1, 0, 0, 0, 0, time.UTC)
+	cal := Calendar{Name: "test", Timezone: "UTC"}
+
+	events, err := safeIcalParse(cal, strings.NewReader(ical), start, start.AddDate(0, 0, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(events) != 1 {
+		t.Fatalf("got %d events, want 1", len(events))
+	}
+
+	entry := NewCalendarEntryFromGocalEvent(cal, events[0])
+
+	tests := []struct {
+		field string
+		got   string
+		want  string
+	}{
+		{"title", entry.Title, `Path C:\new`},
+		{"location", entry.Location, "Room 1, floor 2; east wing"},
+		{"description", entry.Description, "First line\nSecond line\nthird \\\n and a folded esc\nape"},
+		{"categories", strings.Join(entry.Categories, "|"), `Back\slash|Trailing\|Plain`},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %q, want %q", tt.field, tt.got, tt.want)
+		}
+	}
+}
+
+func TestParseIcalKeepsNewValidators(t *testing.T) {
+	ical := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"BEGIN:VEVENT",
+		"UID:validators@example.com",
+		"DTSTAMP:20250401T000000Z",
+		"DTSTART:20250401T090000Z",
+		"DTEND:20250401T100000Z",
+		"SUMMARY:Unchanged",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+
+	// the server rotates its ETag on every response, but always serves the same body
+	var served atomic.Int32
+	var ifNoneMatch atomic.Value
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ifNoneMatch.Store(r.Header.Get("If-None-Match"))
+		w.Header().Set("ETag", fmt.Sprintf(`"v%d"`, served.Add(1)))
+		_, _ = w.Write([]byte(ical))
+	}))
+	defer server.Close()
+
+	e := NewICalClient(NewMemoryStatusStore())
+	cal := Calendar{Name: "validators", From: "url", Ical: server.URL, Timezone: "UTC"}
+	start := time.Date(2025, time.April, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 1)
+
+	for i := 0; i < 2; i++ {
+		data, err := e.getIcalFromURL(context.Background(), cal)
+		if err != nil {
+			t.Fatalf("unable to fetch the calendar: %v", err.Display())
+		}
+
+		if _, err := e.parseIcal(context.Background(), cal, data, start, end); err != nil {
+			t.Fatalf("unable to parse the calendar: %v", err.Display())
+		}
+	}
+
+	if etag := e.getCalendarState(cal).ical.etag; etag != `"v2"` {
+		t.Errorf("stored ETag %s, want the one of the latest response", etag)
+	}
+
+	if _, err := e.getIcalFromURL(context.Background(), cal); err != nil {
+		t.Fatalf("unable to fetch the calendar: %v", err.Display())
+	}
+
+	if sent := ifNoneMatch.Load(); sent != `"v2"` {
+		t.Errorf("sent If-None-Match %v, want the latest ETag", sent)
+	}
+}
+
+func TestCalendarWindow(t *testing.T) {
+	t.Cleanup(viper.Reset)
+	viper.Set("server.timezone", "Europe/Berlin")
+	viper.Set("server.lookBack", 12*time.Hour)
+	viper.Set("server.lookAhead", 48*time.Hour)
+
+	// 23:30 in Berlin, but already the next day in Tokyo
+	now := time.Date(2025, time.April, 1, 21, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		cal       Calendar
+		wantStart string
+		wantEnd   string
+	}{
+		{
+			name:      "server window",
+			cal:       Calendar{Name: "work"},
+			wantStart: "2025-03-31T12:00:00+02:00",
+			wantEnd:   "2025-04-04T00:00:00+02:00",
+		},
+		{
+			name:      "own look back and ahead",
+			cal:       Calendar{Name: "work", LookBack: time.Hour, LookAhead: 24 * time.Hour},
+			wantStart: "2025-03-31T23:00:00+02:00",
+			wantEnd:   "2025-04-03T00:00:00+02:00",
+		},
+		{
+			name:      "own time zone",
+			cal:       Calendar{Name: "work", Timezone: "Asia/Tokyo"},
+			wantStart: "2025-04-01T12:00:00+09:00",
+			wantEnd:   "2025-04-05T00:00:00+09:00",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end := tt.cal.Window(now)
+			if got := start.Format(time.RFC3339); got != tt.wantStart {
+				t.Errorf("window starts %s, want %s", got, tt.wantStart)
+			}
+
+			if got := end.Format(time.RFC3339); got != tt.wantEnd {
+				t.Errorf("window ends %s, want %s", got, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestGetEventsRange(t *testing.T) {
+	day := time.Date(2025, time.April, 1, 0, 0, 0, 0, time.UTC)
+	at := func(hour int) int64 { return day.Add(time.Duration(hour) * time.Hour).Unix() }
+
+	e := NewICalClient(NewMemoryStatusStore())
+	e.cache = &pb.CalendarResponse{
+		From: at(0),
+		To:   at(72),
+		Entries: []*pb.CalendarEntry{
+			{Title: "Today", CalendarName: "work", Start: at(9), End: at(10)},
+			{Title: "Overnight", CalendarName: "work", Start: at(22), End: at(26)},
+			{Title: "Tomorrow", CalendarName: "work", Start: at(33), End: at(34)},
+			{Title: "Other calendar", CalendarName: "home", Start: at(9), End: at(10)},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		from, to int64
+		want     []string // titles, nil if the range is rejected
+	}{
+		{name: "whole window", want: []string{"Today", "Overnight", "Tomorrow"}},
+		{name: "first day", to: at(24), want: []string{"Today", "Overnight"}},
+		{name: "from only", from: at(24), want: []string{"Overnight", "Tomorrow"}},
+		{name: "ending at an event's start", from: at(0), to: at(9), want: []string{}},
+		{name: "before the window", from: at(-1), to: at(24)},
+		{name: "after the window", from: at(24), to: at(73)},
+		{name: "empty range", from: at(24), to: at(24)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events, err := e.GetEvents(context.Background(), "work", tt.from, tt.to)
+			if tt.want == nil {
+				if err == nil {
+					t.Fatal("expected the range to be rejected")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err.Display())
+			}
+
+			got := make([]string, 0, len(events.Entries))
+			for _, entry := range events.Entries {
+				got = append(got, entry.Title)
+			}
+
+			if strings.Join(got, ", ") != strings.Join(tt.want, ", ") {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+
+			if tt.from != 0 && events.From != tt.from || tt.from == 0 && events.From != at(0) {
+				t.Errorf("response starts %d, want the requested or cached start", events.From)
+			}
+		})
+	}
+}
+
+// writeTestCalendar writes an iCal file with an hour-long event per title, one after the other
+// from start on
+func writeTestCalendar(t *testing.T, path string, start time.Time, titles ...string) {
+	t.Helper()
+
+	lines := []string{"BEGIN:VCALENDAR", "VERSION:2.0"}
+	for i, title := range titles {
+		eventStart := start.Add(time.Duration(i) * time.Hour).UTC()
+		lines = append(lines,
+			"BEGIN:VEVENT",
+			fmt.Sprintf("UID:%d@example.com", i),
+			"DTSTAMP:20250101T000000Z",
+			"DTSTART:"+eventStart.Format("20060102T150405Z"),
+			"DTEND:"+eventStart.Add(time.Hour).Format("20060102T150405Z"),
+			"SUMMARY:"+title,
+			"END:VEVENT",
+		)
+	}
+	lines = append(lines, "END:VCALENDAR")
+
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\r\n")), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRefreshCalendarServesLastKnownGood(t *testing.T) {
+	t.Cleanup(viper.Reset)
+	viper.Set("server.lookAhead", 48*time.Hour)
+	viper.Set("server.maxStaleness", time.Hour)
+	viper.Set("rules", []map[string]any{{"name": "all", "key": "*", "contains": []string{"*"}}})
+
+	path := filepath.Join(t.TempDir(), "work.ics")
+	writeTestCalendar(t, path, time.Now().Add(24*time.Hour), "Standup", "Review")
+
+	e := NewICalClient(NewMemoryStatusStore())
+	cal := Calendar{Name: "work", From: "file", Ical: path}
+
+	events, info := e.refreshCalendar(context.Background(), cal, time.Now(), parseRules())
+	if len(events) != 2 || info.Stale || info.LastError != "" || info.LastSuccess == 0 {
+		t.Fatalf("first refresh: %d events, info %v", len(events), info)
+	}
+	lastSuccess := info.LastSuccess
+
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+
+	// the calendar can't be loaded anymore, so the last known good events are served
+	events, info = e.refreshCalendar(context.Background(), cal, time.Now(), parseRules())
+	if len(events) != 2 || !info.Stale || info.EventCount != 2 {
+		t.Errorf("failing refresh: %d events, stale %v, want the 2 last known good events", len(events), info.Stale)
+	}
+
+	if info.LastError == "" || info.LastSuccess != lastSuccess {
+		t.Errorf("failing refresh: error %q and last success %d, want the error and %d", info.LastError, info.LastSuccess, lastSuccess)
+	}
+
+	// until they are too old
+	cal.MaxStaleness = time.Nanosecond
+	events, info = e.refreshCalendar(context.Background(), cal, time.Now(), parseRules())
+	if len(events) != 0 || info.Stale {
+		t.Errorf("refresh past the staleness limit: %d events, stale %v, want none", len(events), info.Stale)
+	}
+
+	if info.LastSuccess != lastSuccess {
+		t.Errorf("refresh past the staleness limit: last success %d, want %d", info.LastSuccess, lastSuccess)
+	}
+}
+
+func TestListCalendars(t *testing.T) {
+	dir := t.TempDir()
+	writeTestCalendar(t, filepath.Join(dir, "work.ics"), time.Now().Add(24*time.Hour), "Standup", "Review")
+
+	t.Cleanup(viper.Reset)
+	viper.Set("server.lookAhead", 48*time.Hour)
+	viper.Set("rules", []map[string]any{{"name": "all", "key": "*", "contains": []string{"*"}}})
+	viper.Set("calendars", []map[string]any{
+		{"name": "work", "from": "file", "ical": filepath.Join(dir, "work.ics"), "timezone": "Europe/Berlin"},
+		{"name": "broken", "from": "file", "ical": filepath.Join(dir, "missing.ics")},
+	})
+
+	e := NewICalClient(NewMemoryStatusStore())
+	e.FetchEvents(context.Background())
+
+	calendars := e.ListCalendars(context.Background()).Calendars
+	if len(calendars) != 2 {
+		t.Fatalf("got %d calendars, want 2", len(calendars))
+	}
+
+	// calendars are sorted by name
+	broken, work := calendars[0], calendars[1]
+	if broken.Name != "broken" || work.Name != "work" {
+		t.Fatalf("got calendars %s and %s, want broken and work", broken.Name, work.Name)
+	}
+
+	if work.From != "file" || work.EventCount != 2 || work.LastError != "" || work.LastSuccess == 0 || work.Timezone != "Europe/Berlin" {
+		t.Errorf("unexpected state of a healthy calendar: %v", work)
+	}
+
+	if work.LastRefresh == 0 || work.Duration < 0 {
+		t.Errorf("the refresh of the healthy calendar wasn't recorded: %v", work)
+	}
+
+	if broken.EventCount != 0 || !strings.Contains(broken.LastError, "missing.ics") || broken.LastSuccess != 0 || broken.Stale {
+		t.Errorf("unexpected state of a calendar that never loaded: %v", broken)
+	}
+}
+
+func TestRefreshCalendar(t *testing.T) {
+	dir := t.TempDir()
+	start := time.Now().Add(24 * time.Hour)
+	writeTestCalendar(t, filepath.Join(dir, "work.ics"), start, "Standup")
+	writeTestCalendar(t, filepath.Join(dir, "home.ics"), start.Add(30*time.Minute), "Dinner")
+
+	t.Cleanup(viper.Reset)
+	viper.Set("server.lookAhead", 48*time.Hour)
+	viper.Set("rules", []map[string]any{{"name": "all", "key": "*", "contains": []string{"*"}}})
+	viper.Set("calendars", []map[string]any{
+		{"name": "work", "from": "file", "ical": filepath.Join(dir, "work.ics")},
+		{"name": "home", "from": "file", "ical": filepath.Join(dir, "home.ics")},
+	})
+
+	e := NewICalClient(NewMemoryStatusStore())
+	e.FetchEvents(context.Background())
+
+	titles := func() string {
+		events, err := e.GetEvents(context.Background(), "all", 0, 0)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err.Display())
+		}
+
+		got := make([]string, 0, len(events.Entries))
+		for _, entry := range events.Entries {
+			got = append(got, entry.Title)
+		}
+		return strings.Join(got, ", ")
+	}
+
+	writeTestCalendar(t, filepath.Join(dir, "work.ics"), start, "Standup", "Review")
+	writeTestCalendar(t, filepath.Join(dir, "home.ics"), start.Add(30*time.Minute), "Dinner", "Movie")
+
+	// only the refreshed calendar changes
+	changed := e.Changed()
+	resp, err := e.RefreshCalendar(context.Background(), "work")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err.Display())
+	}
+
+	select {
+	case <-changed:
+	default:
+		t.Error("watchers weren't notified about the refresh")
+	}
+
+	if len(resp.Calendars) != 1 || resp.Calendars[0].Name != "work" || resp.Calendars[0].EventCount != 2 {
+		t.Errorf("unexpected outcome of the refresh: %v", resp.Calendars)
+	}
+
+	if got := titles(); got != "Standup, Dinner, Review" {
+		t.Errorf("got events %s, want the new ones of work and the old ones of home", got)
+	}
+
+	if calendars := e.ListCalendars(context.Background()).Calendars; len(calendars) != 2 || calendars[0].Name != "home" {
+		t.Errorf("the calendars weren't kept sorted: %v", calendars)
+	}
+
+	resp, err = e.RefreshCalendar(context.Background(), "all")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err.Display())
+	}
+
+	if len(resp.Calendars) != 2 {
+		t.Errorf("refreshing all calendars reported %d calendars, want 2", len(resp.Calendars))
+	}
+
+	if got := titles(); got != "Standup, Dinner, Review, Movie" {
+		t.Errorf("got events %s, want the new ones of both calendars", got)
+	}
+
+	if _, err := e.RefreshCalendar(context.Background(), "unknown"); err == nil {
+		t.Error("expected refreshing an unknown calendar to fail")
+	}
+}
+
+func TestNewCalendarEntryFromGocalEventState(t *testing.T) {
+	timed := []string{"DTSTART:20250401T090000Z", "DTEND:20250401T100000Z"}
+	allDay := []string{"DTSTART;VALUE=DATE:20250401", "DTEND;VALUE=DATE:20250402"}
+
+	tests := []struct {
+		name    string
+		times   []string
+		extra   []string
+		skipped bool
+		busy    pb.BusyState
+		allDay  bool
+	}{
+		{name: "plain", busy: pb.BusyState_Busy},
+		{name: "opaque", extra: []string{"TRANSP:OPAQUE"}, busy: pb.BusyState_Busy},
+		{name: "transparent", extra: []string{"TRANSP:TRANSPARENT"}, busy: pb.BusyState_Free},
+		{name: "tentative", extra: []string{"STATUS:TENTATIVE"}, busy: pb.BusyState_Tentative},
+		{name: "cancelled", extra: []string{"STATUS:CANCELLED"}, skipped: true},
+		{name: "declined by the owner", extra: []string{"ATTENDEE;PARTSTAT=DECLINED:mailto:Me@example.com"}, skipped: true},
+		{name: "declined by someone else", extra: []string{"ATTENDEE;PARTSTAT=DECLINED:mailto:bob@example.com"}, busy: pb.BusyState_Busy},
+		{name: "tentatively accepted by the owner", extra: []string{"ATTENDEE;PARTSTAT=TENTATIVE:mailto:me@example.com"}, busy: pb.BusyState_Tentative},
+		{name: "Exchange busy status", extra: []string{"TRANSP:TRANSPARENT", "X-MICROSOFT-CDO-BUSYSTATUS:OOF"}, busy: pb.BusyState_OutOfOffice},
+		{name: "all-day", times: allDay, extra: []string{"TRANSP:TRANSPARENT"}, busy: pb.BusyState_Free, allDay: true},
+		{name: "Exchange all-day event", extra: []string{"X-MICROSOFT-CDO-ALLDAYEVENT:TRUE"}, busy: pb.BusyState_Busy, allDay: true},
+	}
+
+	start := time.Date(2025, time.April, 1, 0, 0, 0, 0, time.UTC)
+	cal := Calendar{Name: "test", Timezone: "UTC", Owner: "mailto:me@example.com"}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			times := tt.times
+			if times == nil {
+				times = timed
+			}
+
+			lines := []string{"BEGIN:VCALENDAR", "VERSION:2.0", "BEGIN:VEVENT", "UID:state@example.com", "DTSTAMP:20250101T000000Z", "SUMMARY:Event"}
+			lines = append(lines, times...)
+			lines = append(lines, tt.extra...)
+			lines = append(lines, "END:VEVENT", "END:VCALENDAR")
+
+			events, err := safeIcalParse(cal, strings.NewReader(strings.Join(lines, "\r\n")), start, start.AddDate(0, 0, 1))
+			if err != nil || len(events) != 1 {
+				t.Fatalf("unable to parse the event: %v", err)
+			}
+
+			entry := NewCalendarEntryFromGocalEvent(cal, events[0])
+			if tt.skipped {
+				if entry != nil {
+					t.Errorf("the event wasn't skipped: %v", entry)
+				}
+				return
+			}
+
+			if entry == nil {
+				t.Fatal("the event was skipped")
+			}
+
+			if entry.Busy != tt.busy || entry.AllDay != tt.allDay {
+				t.Errorf("busy %s and all-day %v, want %s and %v", entry.Busy, entry.AllDay, tt.busy, tt.allDay)
+			}
+		})
+	}
+}
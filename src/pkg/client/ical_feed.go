@@ -0,0 +1,157 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	pb "github.com/SpechtLabs/CalendarAPI/pkg/protos"
+)
+
+// busyStatuses maps the busy states to the X-MICROSOFT-CDO-BUSYSTATUS the entries were read from
+var busyStatuses = map[pb.BusyState]string{
+	pb.BusyState_Free:             "FREE",
+	pb.BusyState_WorkingElsewhere: "WORKINGELSEWHERE",
+	pb.BusyState_Tentative:        "TENTATIVE",
+	pb.BusyState_Busy:             "BUSY",
+	pb.BusyState_OutOfOffice:      "OOF",
+}
+
+// escapeText escapes TEXT values, the reverse of icalText
+var escapeText = strings.NewReplacer(`\`, `\\`, "\n", `\n`, ",", `\,`, ";", `\;`)
+
+// MarshalCalendarICal renders the processed entries of the calendar as RFC 5545 iCal document,
+// so calendar apps can subscribe to the view the rules and privacy masking produce
+func MarshalCalendarICal(calendar *pb.CalendarResponse) []byte {
+	var w icalWriter
+
+	w.line("BEGIN", "VCALENDAR")
+	w.line("VERSION", "2.0")
+	w.line("PRODID", "-//SpechtLabs//CalendarAPI//EN")
+	w.line("METHOD", "PUBLISH")
+	w.line("X-WR-CALNAME", escapeText.Replace(calendar.CalendarName))
+	if calendar.Timezone != "" {
+		w.line("X-WR-TIMEZONE", calendar.Timezone)
+	}
+
+	stamp := icalTime(time.Now().Unix())
+	for _, entry := range calendar.Entries {
+		writeEvent(&w, entry, stamp)
+	}
+
+	w.line("END", "VCALENDAR")
+
+	return w.Bytes()
+}
+
+// writeEvent writes the entry as VEVENT. All-day events are dated in the entry's time zone, all
+// other times are written in UTC, so the feed doesn't need any VTIMEZONE.
+func writeEvent(w *icalWriter, entry *pb.CalendarEntry, stamp string) {
+	w.line("BEGIN", "VEVENT")
+	w.line("UID", escapeText.Replace(eventUID(entry)))
+	w.line("DTSTAMP", stamp)
+
+	if entry.AllDay {
+		loc := Calendar{Name: entry.CalendarName, Timezone: entry.Timezone}.Location()
+		start, end := time.Unix(entry.Start, 0).In(loc), time.Unix(entry.End, 0).In(loc)
+
+		// DTEND is exclusive, but events may end just before midnight
+		endDate := time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, loc)
+		if endDate.Before(end) || !endDate.After(start) {
+			endDate = endDate.AddDate(0, 0, 1)
+		}
+
+		w.line("DTSTART;VALUE=DATE", start.Format("20060102"))
+		w.line("DTEND;VALUE=DATE", endDate.Format("20060102"))
+	} else {
+		w.line("DTSTART", icalTime(entry.Start))
+		w.line("DTEND", icalTime(entry.End))
+	}
+
+	w.line("SUMMARY", escapeText.Replace(entry.Title))
+
+	if entry.Location != "" {
+		w.line("LOCATION", escapeText.Replace(entry.Location))
+	}
+
+	if entry.Description != "" {
+		w.line("DESCRIPTION", escapeText.Replace(entry.Description))
+	}
+
+	if entry.Url != "" {
+		w.line("URL", entry.Url)
+	}
+
+	if len(entry.Categories) > 0 {
+		categories := make([]string, 0, len(entry.Categories))
+		for _, category := range entry.Categories {
+			categories = append(categories, escapeText.Replace(category))
+		}
+		w.line("CATEGORIES", strings.Join(categories, ","))
+	}
+
+	if entry.Organizer != nil && entry.Organizer.Email != "" {
+		w.line("ORGANIZER"+commonName(entry.Organizer.Name), "mailto:"+entry.Organizer.Email)
+	}
+
+	for _, attendee := range entry.Attendees {
+		if attendee.Email == "" {
+			continue
+		}
+
+		params := commonName(attendee.Name)
+		if attendee.Status != "" {
+			params += ";PARTSTAT=" + attendee.Status
+		}
+		w.line("ATTENDEE"+params, "mailto:"+attendee.Email)
+	}
+
+	if entry.Busy == pb.BusyState_Free {
+		w.line("TRANSP", "TRANSPARENT")
+	} else {
+		w.line("TRANSP", "OPAQUE")
+	}
+
+	if entry.Busy == pb.BusyState_Tentative {
+		w.line("STATUS", "TENTATIVE")
+	}
+
+	w.line("X-MICROSOFT-CDO-BUSYSTATUS", busyStatuses[entry.Busy])
+
+	if entry.Important {
+		w.line("PRIORITY", "1")
+	}
+
+	if entry.Private {
+		w.line("CLASS", "PRIVATE")
+	}
+
+	w.line("END", "VEVENT")
+}
+
+// eventUID identifies the occurrence of the event in the feed. Occurrences of recurring events
+// share their UID, as do events listed in several calendars, so the calendar and start are part of it.
+func eventUID(entry *pb.CalendarEntry) string {
+	uid := entry.Uid
+	if uid == "" {
+		uid = "event@calendarapi"
+	}
+
+	return fmt.Sprintf("%s-%d-%s", entry.CalendarName, entry.Start, uid)
+}
+
+// commonName returns the CN parameter for the name, empty if there is no name
+func commonName(name string) string {
+	if name == "" {
+		return ""
+	}
+
+	// parameter values can't contain quotes, so they are dropped, and need quoting if they contain
+	// any of the delimiters
+	name = strings.ReplaceAll(name, `"`, "")
+	if strings.ContainsAny(name, ":;,") {
+		name = `"` + name + `"`
+	}
+
+	return ";CN=" + name
+}
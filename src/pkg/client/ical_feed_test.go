@@ -0,0 +1,135 @@
+package client
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	pb "github.com/SpechtLabs/CalendarAPI/pkg/protos"
+)
+
+func TestMarshalCalendarICalFoldingAndEscaping(t *testing.T) {
+	title := `Café ☕ Planung; Q3, Budget \ Ziele für 2026 – Überblick über alle Teams 🚀 und nächste Schritte`
+	description := "Agenda:\n1. Rückblick, Ausblick; Fragen\n2. C:\\new\\path"
+
+	start := time.Date(2025, time.April, 1, 9, 0, 0, 0, time.UTC)
+	feed := MarshalCalendarICal(&pb.CalendarResponse{
+		CalendarName: "work",
+		Entries: []*pb.CalendarEntry{{
+			Title:        title,
+			Description:  description,
+			Location:     "Raum 1, Flur 2; Ostflügel",
+			Categories:   []string{"Planung, intern", `Back\slash`},
+			Start:        start.Unix(),
+			End:          start.Add(time.Hour).Unix(),
+			CalendarName: "work",
+			Uid:          "feed@example.com",
+		}},
+	})
+
+	lines := strings.Split(strings.TrimSuffix(string(feed), "\r\n"), "\r\n")
+	for _, line := range lines {
+		if len(line) > 75 {
+			t.Errorf("line is %d octets long: %q", len(line), line)
+		}
+
+		if !utf8.ValidString(line) {
+			t.Errorf("line splits a multi-byte character: %q", line)
+		}
+
+		if strings.Contains(line, "\n") {
+			t.Errorf("line contains a bare line break: %q", line)
+		}
+	}
+
+	unfolded := strings.ReplaceAll(string(feed), "\r\n ", "")
+
+	for _, want := range []string{
+		`SUMMARY:Café ☕ Planung\; Q3\, Budget \\ Ziele für 2026 – Überblick über alle Teams 🚀 und nächste Schritte`,
+		`DESCRIPTION:Agenda:\n1. Rückblick\, Ausblick\; Fragen\n2. C:\\new\\path`,
+		`LOCATION:Raum 1\, Flur 2\; Ostflügel`,
+		`CATEGORIES:Planung\, intern,Back\\slash`,
+	} {
+		if !strings.Contains(unfolded, want+"\r\n") {
+			t.Errorf("the feed lacks %s", want)
+		}
+	}
+
+	if !strings.Contains(string(feed), "\r\n ") {
+		t.Error("no line was folded")
+	}
+
+	// the feed parses back into the original texts
+	events, err := safeIcalParse(Calendar{Name: "work", Timezone: "UTC"}, strings.NewReader(string(feed)), start.Add(-time.Hour), start.Add(2*time.Hour))
+	if err != nil {
+		t.Fatalf("unable to parse the feed: %v", err.Display())
+	}
+
+	if len(events) != 1 {
+		t.Fatalf("parsed %d events, want 1", len(events))
+	}
+
+	entry := NewCalendarEntryFromGocalEvent(Calendar{Name: "work", Timezone: "UTC"}, events[0])
+	if entry.Title != title {
+		t.Errorf("title %q, want %q", entry.Title, title)
+	}
+
+	if entry.Description != description {
+		t.Errorf("description %q, want %q", entry.Description, description)
+	}
+}
+
+func TestGetMergedEvents(t *testing.T) {
+	e := NewICalClient(NewMemoryStatusStore())
+	e.cache = &pb.CalendarResponse{
+		From:      100,
+		To:        1000,
+		Timezone:  "UTC",
+		Calendars: []*pb.CalendarInfo{{Name: "room", Timezone: "Europe/Berlin"}, {Name: "home"}, {Name: "work"}},
+		Entries: []*pb.CalendarEntry{
+			{Title: "Booked", CalendarName: "room", Start: 200, End: 300},
+			{Title: "Dentist", CalendarName: "home", Start: 250, End: 300},
+			{Title: "Standup", CalendarName: "work", Start: 300, End: 400},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		calendars []string
+		want      string // the calendar name and the titles of the events
+		wantErr   bool
+	}{
+		{name: "several calendars", calendars: []string{"work", "room"}, want: "work,room: Booked, Standup"},
+		{name: "one calendar", calendars: []string{"room"}, want: "room: Booked"},
+		{name: "all", calendars: []string{"all"}, want: "all: Booked, Dentist, Standup"},
+		{name: "none", want: "all: Booked, Dentist, Standup"},
+		{name: "unknown calendar", calendars: []string{"work", "garage"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events, err := e.GetMergedEvents(context.Background(), tt.calendars, 0, 0)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("expected an error")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err.Display())
+			}
+
+			titles := make([]string, 0, len(events.Entries))
+			for _, entry := range events.Entries {
+				titles = append(titles, entry.Title)
+			}
+
+			if got := events.CalendarName + ": " + strings.Join(titles, ", "); got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package client
+
+import (
+	"bytes"
+	"time"
+	"unicode/utf8"
+)
+
+// icalWriter writes iCal content lines, folding them at 75 octets as RFC 5545 demands
+type icalWriter struct {
+	bytes.Buffer
+}
+
+// line writes the content line name:value
+func (w *icalWriter) line(name string, value string) {
+	line := name + ":" + value
+
+	// continuation lines start with a space, which counts towards their length
+	limit := 75
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+
+		w.WriteString(line[:cut] + "\r\n ")
+		line = line[cut:]
+		limit = 74
+	}
+
+	w.WriteString(line + "\r\n")
+}
+
+// icalTime formats the unix timestamp as iCal UTC date-time
+func icalTime(unix int64) string {
+	return time.Unix(unix, 0).UTC().Format("20060102T150405Z")
+}
@@ -2,6 +2,8 @@ package client
 
 import (
 	"fmt"
+	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -12,95 +14,365 @@ import (
 )
 
 type Rule struct {
-	CalendarName string   `mapstructure:"calendar"`
-	Name         string   `mapstructure:"name"`
-	Key          string   `mapstructure:"key"`
-	Contains     []string `mapstructure:"contains"`
-	Skip         bool     `mapstructure:"skip"`
-	Message      string   `mapstructure:"message"`
-	Important    bool     `mapstructure:"important"`
+	CalendarName string        `mapstructure:"calendar"`
+	Name         string        `mapstructure:"name"`
+	Skip         bool          `mapstructure:"skip"`
+	Relabel      RelabelConfig `mapstructure:"relabelConfig"`
+
+	// Flat versions of relabelConfig's message and important, kept for older configurations
+	Message   *string `mapstructure:"message"`
+	Important *bool   `mapstructure:"important"`
+
+	// The condition is inlined, so simple rules only need 'key' and 'contains'
+	Condition `mapstructure:",squash"`
 }
 
-// Evaluate evaluates a rule against a pb.CalendarEntry and returns (bool, bool)
-// where the first bool indicates if the rule was applied to this pb.CalendarEntry
-// and the second bool indicates if this is a skip rule and the pb.CalendarEntry
-// should be skipped
-func (r *Rule) Evaluate(e *pb.CalendarEntry) (bool, bool) {
-	var matchFieldValue string
-	var matchFieldContains string
-	match := false
+// RelabelConfig rewrites the events a rule matches. Only the actions that are set are applied,
+// everything else is left as it is.
+type RelabelConfig struct {
+	Message   *string `mapstructure:"message"`   // the message to show for the event
+	Important *bool   `mapstructure:"important"` // flags the event as important or not
+	Title     *string `mapstructure:"title"`     // the new title, may use the rule's regex capture groups as $1 or ${name}
+	Icon      *string `mapstructure:"icon"`      // the icon to show for the event
+	Busy      *string `mapstructure:"busy"`      // overrides the busy state, e.g. Free or OutOfOffice
+	Private   *bool   `mapstructure:"private"`   // marks the event as private
 
-	// only evaluate our rule if the calendar matches
-	if r.CalendarName == "" || r.CalendarName == "*" || r.CalendarName == "all" || r.CalendarName == e.CalendarName {
-		switch r.Key {
-		case "title":
-			matchFieldValue = e.Title
+	busy pb.BusyState
+}
+
+// compile prepares the rule's condition and relabel config
+func (r *Rule) compile() error {
+	if err := r.Condition.compile(); err != nil {
+		return err
+	}
+
+	if r.Relabel.Message == nil {
+		r.Relabel.Message = r.Message
+	}
+
+	if r.Relabel.Important == nil {
+		r.Relabel.Important = r.Important
+	}
 
-		case "all_day":
-			matchFieldValue = strconv.FormatBool(e.AllDay)
+	if r.Relabel.Busy != nil {
+		busy, ok := pb.BusyState_value[*r.Relabel.Busy]
+		if !ok {
+			return fmt.Errorf("invalid busy state %q, use one of Free, Tentative, Busy, OutOfOffice or WorkingElsewhere", *r.Relabel.Busy)
+		}
+		r.Relabel.busy = pb.BusyState(busy)
+	}
 
-		case "busy":
-			matchFieldValue = e.Busy.String()
+	return nil
+}
 
-			// if the user wants to match on all possible locations,
-			// let's just concatenate them all in one big string, shall we?
-			// This way we search all fields :D
-		case "*":
-			matchFieldValue = fmt.Sprintf("%s%s%s", e.Title, strconv.FormatBool(e.AllDay), e.Busy.String())
+// Apply rewrites the event. The condition that matched the event provides the capture groups for the title.
+func (rc *RelabelConfig) Apply(e *pb.CalendarEntry, c *Condition) {
+	if rc.Title != nil {
+		if re, value, match := c.submatch(e); re != nil {
+			e.Title = string(re.ExpandString(nil, *rc.Title, value, match))
+		} else {
+			e.Title = *rc.Title
 		}
+	}
 
-		for _, contains := range r.Contains {
-			if contains == "*" {
-				match = true
-			}
+	if rc.Message != nil {
+		e.Message = *rc.Message
+	}
 
-			// compare but ignore case...
-			if strings.Contains(strings.ToLower(matchFieldValue), strings.ToLower(contains)) {
-				match = true
-			}
+	if rc.Important != nil {
+		e.Important = *rc.Important
+	}
+
+	if rc.Icon != nil {
+		e.Icon = *rc.Icon
+	}
+
+	if rc.Busy != nil {
+		e.Busy = rc.busy
+	}
+
+	if rc.Private != nil {
+		e.Private = *rc.Private
+	}
+}
+
+// Condition decides whether a rule applies to an event. All matchers that are set have to match.
+// Conditions without any matcher never match.
+type Condition struct {
+	Key         string   `mapstructure:"key"`          // the field to match, see fieldValues
+	Contains    []string `mapstructure:"contains"`     // the field contains any of these, ignoring case, * or "" match always
+	NotContains []string `mapstructure:"not_contains"` // the field contains none of these, ignoring case
+	Equals      []string `mapstructure:"equals"`       // the field equals any of these, ignoring case
+	Regex       []string `mapstructure:"regex"`        // the field matches any of these regular expressions
+
+	All []Condition `mapstructure:"all"` // every one of these conditions matches
+	Any []Condition `mapstructure:"any"` // at least one of these conditions matches
+	Not bool        `mapstructure:"not"` // negates the whole condition
+
+	regexps []*regexp.Regexp
+}
+
+// compile prepares the regular expressions of the condition and all nested conditions
+func (c *Condition) compile() error {
+	c.regexps = make([]*regexp.Regexp, 0, len(c.Regex))
+	for _, expr := range c.Regex {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return fmt.Errorf("invalid regex %q: %w", expr, err)
+		}
+		c.regexps = append(c.regexps, re)
+	}
+
+	for i := range c.All {
+		if err := c.All[i].compile(); err != nil {
+			return err
+		}
+	}
+
+	for i := range c.Any {
+		if err := c.Any[i].compile(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// fieldKeys are the keys of the event's fields except the calendar, they are all searched by any_field
+var fieldKeys = []string{"title", "all_day", "busy", "location", "organizer", "attendees", "description", "uid", "url", "categories"}
+
+// fieldValues returns the values of the event's field key is referring to. Most fields have a
+// single value, attendees and categories have one value per attendee or category.
+func fieldValues(e *pb.CalendarEntry, key string) []string {
+	switch key {
+	case "title":
+		return []string{e.Title}
+
+	case "all_day":
+		return []string{strconv.FormatBool(e.AllDay)}
+
+	case "busy":
+		return []string{e.Busy.String()}
+
+	case "calendar":
+		return []string{e.CalendarName}
+
+	case "location":
+		return []string{e.Location}
+
+	case "organizer":
+		if e.Organizer == nil {
+			return nil
+		}
+		return []string{formatPerson(e.Organizer.Name, e.Organizer.Email)}
+
+	case "attendees":
+		attendees := make([]string, 0, len(e.Attendees))
+		for _, attendee := range e.Attendees {
+			attendees = append(attendees, formatPerson(attendee.Name, attendee.Email))
+		}
+		return attendees
+
+	case "description":
+		return []string{e.Description}
+
+	case "uid":
+		return []string{e.Uid}
+
+	case "url":
+		return []string{e.Url}
+
+	case "categories":
+		return e.Categories
+
+		// if the user wants to match on all possible locations,
+		// let's just concatenate them all in one big string, shall we?
+		// This way we search all fields :D
+	case "*":
+		return []string{fmt.Sprintf("%s%s%s", e.Title, strconv.FormatBool(e.AllDay), e.Busy.String())}
+
+	case "any_field":
+		var all []string
+		for _, key := range fieldKeys {
+			all = append(all, fieldValues(e, key)...)
+		}
+		return all
+	}
+
+	return nil
+}
+
+// formatPerson formats a person as "Name <email>", so rules can match on either
+func formatPerson(name string, email string) string {
+	if name == "" {
+		return email
+	}
+
+	return fmt.Sprintf("%s <%s>", name, email)
+}
 
-			if match {
-				matchFieldContains = contains
-				break
+// submatch returns the first regular expression of the condition or its nested conditions that
+// matches the event, together with the matched value and the submatch indices
+func (c *Condition) submatch(e *pb.CalendarEntry) (*regexp.Regexp, string, []int) {
+	// a negated condition matches when its regular expressions don't, so there is nothing to capture
+	if c.Not {
+		return nil, "", nil
+	}
+
+	for _, re := range c.regexps {
+		for _, value := range fieldValues(e, c.Key) {
+			if match := re.FindStringSubmatchIndex(value); match != nil {
+				return re, value, match
 			}
 		}
 	}
 
+	for _, sub := range slices.Concat(c.All, c.Any) {
+		if re, value, match := sub.submatch(e); re != nil {
+			return re, value, match
+		}
+	}
+
+	return nil, "", nil
+}
+
+// Matches reports whether the condition matches the event. For fields with several values, like
+// attendees, it is enough if one of the values matches; not_contains requires that none does.
+func (c *Condition) Matches(e *pb.CalendarEntry) bool {
+	original := fieldValues(e, c.Key)
+	values := make([]string, 0, len(original))
+	for _, value := range original {
+		values = append(values, strings.ToLower(value))
+	}
+
+	// anyValueContains reports whether one of the values contains the substring, ignoring case
+	anyValueContains := func(substr string) bool {
+		return slices.ContainsFunc(values, func(value string) bool {
+			return strings.Contains(value, strings.ToLower(substr))
+		})
+	}
+
+	matchers := 0
+	match := true
+
+	if len(c.Contains) > 0 {
+		matchers++
+		match = match && slices.ContainsFunc(c.Contains, func(contains string) bool {
+			// * and the empty string match every event, even if the field has no value at all
+			return contains == "*" || contains == "" || anyValueContains(contains)
+		})
+	}
+
+	if len(c.NotContains) > 0 {
+		matchers++
+		match = match && !slices.ContainsFunc(c.NotContains, anyValueContains)
+	}
+
+	if len(c.Equals) > 0 {
+		matchers++
+		match = match && slices.ContainsFunc(c.Equals, func(equals string) bool {
+			return slices.Contains(values, strings.ToLower(equals))
+		})
+	}
+
+	if len(c.regexps) > 0 {
+		matchers++
+		match = match && slices.ContainsFunc(c.regexps, func(re *regexp.Regexp) bool {
+			return slices.ContainsFunc(original, re.MatchString)
+		})
+	}
+
+	if len(c.All) > 0 {
+		matchers++
+		match = match && !slices.ContainsFunc(c.All, func(sub Condition) bool {
+			return !sub.Matches(e)
+		})
+	}
+
+	if len(c.Any) > 0 {
+		matchers++
+		match = match && slices.ContainsFunc(c.Any, func(sub Condition) bool {
+			return sub.Matches(e)
+		})
+	}
+
+	if matchers == 0 {
+		return false
+	}
+
+	return match != c.Not
+}
+
+// Evaluate evaluates a rule against a pb.CalendarEntry and returns (bool, bool)
+// where the first bool indicates if the rule was applied to this pb.CalendarEntry
+// and the second bool indicates if this is a skip rule and the pb.CalendarEntry
+// should be skipped
+func (r *Rule) Evaluate(e *pb.CalendarEntry) (bool, bool) {
+	match := false
+
+	// only evaluate our rule if the calendar matches
+	if r.CalendarName == "" || r.CalendarName == "*" || r.CalendarName == "all" || r.CalendarName == e.CalendarName {
+		match = r.Matches(e)
+	}
+
 	// The rule doesn't match, so we also don't skip
 	if !match {
 		return false, false
 	}
 
 	// perform the relabelings
-	if e.Message != r.Message {
-		e.Message = r.Message
-	}
-
-	if e.Important != r.Important {
-		e.Important = r.Important
-	}
+	r.Relabel.Apply(e, &r.Condition)
 
 	otelzap.L().Sugar().Debugw("Rule Evaluated",
 		zap.String("rule_name", r.Name),
 		zap.String("calendar_name", r.CalendarName),
 		zap.String("title", e.Title),
 		zap.String("key", r.Key),
-		zap.String("Field", matchFieldValue),
-		zap.String("contains", matchFieldContains),
 		zap.Bool("skip", r.Skip),
 		zap.Bool("relabel_important", e.Important),
 		zap.String("relabel_message", e.Message),
+		zap.String("relabel_title", e.Title),
+		zap.String("relabel_icon", e.Icon),
+		zap.String("relabel_busy", e.Busy.String()),
+		zap.Bool("relabel_private", e.Private),
 	)
 
 	return true, r.Skip
 }
 
+// applyRules evaluates the rules in order against the event and returns the first rule that
+// matched, or nil if none did
+func applyRules(e *pb.CalendarEntry, rules []Rule) *Rule {
+	for i := range rules {
+		if ok, _ := rules[i].Evaluate(e); ok {
+			return &rules[i]
+		}
+	}
+
+	return nil
+}
+
 func parseRules() []Rule {
-	var rules []Rule
-	err := viper.UnmarshalKey("rules", &rules)
+	rules, err := unmarshalRules(viper.GetViper())
 	if err != nil {
 		otelzap.L().WithError(err).Error("Failed to parse rules")
 		return nil
 	}
-	return rules
+
+	valid := make([]Rule, 0, len(rules))
+	for _, rule := range rules {
+		if err := rule.compile(); err != nil {
+			otelzap.L().WithError(err).Error("Ignoring invalid rule", zap.String("rule_name", rule.Name))
+			continue
+		}
+		valid = append(valid, rule)
+	}
+
+	return valid
+}
+
+func unmarshalRules(v *viper.Viper) ([]Rule, error) {
+	var rules []Rule
+	err := v.UnmarshalKey("rules", &rules)
+	return rules, err
 }
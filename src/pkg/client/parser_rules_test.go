@@ -0,0 +1,262 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/SpechtLabs/CalendarAPI/pkg/protos"
+)
+
+func TestConditionMatches(t *testing.T) {
+	standup := &pb.CalendarEntry{
+		Title:        "Team Standup",
+		CalendarName: "work",
+		Busy:         pb.BusyState_Busy,
+		Organizer:    &pb.Person{Name: "Alice", Email: "alice@example.com"},
+		Attendees: []*pb.Attendee{
+			{Name: "Bob", Email: "bob@example.com"},
+			{Email: "carol@partner.example"},
+		},
+		Categories: []string{"Meeting", "Daily"},
+	}
+
+	// an event without organizer, attendees or categories
+	focus := &pb.CalendarEntry{Title: "Focus time", CalendarName: "work", Busy: pb.BusyState_Free}
+
+	tests := []struct {
+		name      string
+		condition Condition
+		standup   bool
+		focus     bool
+	}{
+		{name: "no matcher", condition: Condition{Key: "title"}},
+		{name: "contains", condition: Condition{Key: "title", Contains: []string{"STANDUP"}}, standup: true},
+		{name: "contains wildcard", condition: Condition{Key: "*", Contains: []string{"*"}}, standup: true, focus: true},
+		{name: "contains empty string", condition: Condition{Key: "title", Contains: []string{""}}, standup: true, focus: true},
+		{name: "contains empty string of a missing organizer", condition: Condition{Key: "organizer", Contains: []string{""}}, standup: true, focus: true},
+		{name: "contains wildcard without attendees", condition: Condition{Key: "attendees", Contains: []string{"*"}}, standup: true, focus: true},
+		{name: "not_contains", condition: Condition{Key: "title", NotContains: []string{"standup"}}, focus: true},
+		{name: "equals", condition: Condition{Key: "busy", Equals: []string{"free"}}, focus: true},
+		{name: "unknown key", condition: Condition{Key: "color", Contains: []string{"red"}}},
+
+		{name: "any attendee contains", condition: Condition{Key: "attendees", Contains: []string{"partner.example"}}, standup: true},
+		{name: "any attendee equals", condition: Condition{Key: "attendees", Equals: []string{"bob <bob@example.com>"}}, standup: true},
+		{name: "no attendee contains", condition: Condition{Key: "attendees", NotContains: []string{"partner.example"}}, focus: true},
+		{name: "any category matches", condition: Condition{Key: "categories", Regex: []string{"^Daily$"}}, standup: true},
+		{name: "organizer by email", condition: Condition{Key: "organizer", Contains: []string{"alice@"}}, standup: true},
+		{name: "wildcard searches title, all day and busy", condition: Condition{Key: "*", Contains: []string{"standupfalsebusy"}}, standup: true},
+		{name: "wildcard ignores the other fields", condition: Condition{Key: "*", Contains: []string{"daily"}}},
+		{name: "any field searches all fields", condition: Condition{Key: "any_field", Contains: []string{"daily"}}, standup: true},
+		{name: "any field searches every attendee", condition: Condition{Key: "any_field", Equals: []string{"carol@partner.example"}}, standup: true},
+		{name: "no field contains", condition: Condition{Key: "any_field", NotContains: []string{"alice"}}, focus: true},
+
+		{name: "regex is case sensitive", condition: Condition{Key: "title", Regex: []string{"standup"}}},
+		{name: "regex ignoring case", condition: Condition{Key: "title", Regex: []string{"(?i)standup"}}, standup: true},
+
+		{
+			name:      "matchers of one condition all match",
+			condition: Condition{Key: "title", Contains: []string{"team"}, NotContains: []string{"standup"}},
+		},
+		{
+			name: "all",
+			condition: Condition{All: []Condition{
+				{Key: "calendar", Equals: []string{"work"}},
+				{Key: "busy", Equals: []string{"Busy"}},
+			}},
+			standup: true,
+		},
+		{
+			name: "any",
+			condition: Condition{Any: []Condition{
+				{Key: "title", Contains: []string{"focus"}},
+				{Key: "categories", Equals: []string{"meeting"}},
+			}},
+			standup: true,
+			focus:   true,
+		},
+		{
+			name:      "not",
+			condition: Condition{Key: "title", Contains: []string{"standup"}, Not: true},
+			focus:     true,
+		},
+		{
+			name: "nested not",
+			condition: Condition{All: []Condition{
+				{Key: "calendar", Equals: []string{"work"}},
+				{Any: []Condition{{Key: "attendees", Contains: []string{"*"}}}, Not: true},
+			}},
+		},
+		{
+			name:      "not without matcher",
+			condition: Condition{Key: "title", Not: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.condition.compile(); err != nil {
+				t.Fatalf("unable to compile the condition: %v", err)
+			}
+
+			if got := tt.condition.Matches(standup); got != tt.standup {
+				t.Errorf("matches the standup: %v, want %v", got, tt.standup)
+			}
+
+			if got := tt.condition.Matches(focus); got != tt.focus {
+				t.Errorf("matches the focus time: %v, want %v", got, tt.focus)
+			}
+		})
+	}
+}
+
+func TestRelabelConfigApply(t *testing.T) {
+	title := func(title string) *string { return &title }
+
+	tests := []struct {
+		name      string
+		condition Condition
+		title     *string
+		want      string
+	}{
+		{
+			name:      "numbered groups",
+			condition: Condition{Key: "title", Regex: []string{`^1:1 (\w+) / (\w+)$`}},
+			title:     title("Meeting $2 and $1"),
+			want:      "Meeting Bob and Alice",
+		},
+		{
+			name:      "named groups",
+			condition: Condition{Key: "title", Regex: []string{`^1:1 (?P<first>\w+) / (?P<second>\w+)$`}},
+			title:     title("1:1 with ${second}"),
+			want:      "1:1 with Bob",
+		},
+		{
+			name: "groups of a nested condition",
+			condition: Condition{All: []Condition{
+				{Key: "calendar", Equals: []string{"work"}},
+				{Key: "attendees", Regex: []string{`^(\w+) <`}},
+			}},
+			title: title("Meeting with $1"),
+			want:  "Meeting with Carol",
+		},
+		{
+			name:      "without regex",
+			condition: Condition{Key: "title", Contains: []string{"1:1"}},
+			title:     title("One on one $1"),
+			want:      "One on one $1",
+		},
+		{
+			name:      "negated regex",
+			condition: Condition{Key: "title", Regex: []string{`^Standup`}, Not: true},
+			title:     title("Not a standup $0"),
+			want:      "Not a standup $0",
+		},
+		{
+			name:      "title unchanged",
+			condition: Condition{Key: "title", Regex: []string{`(\w+)`}},
+			want:      "1:1 Alice / Bob",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.condition.compile(); err != nil {
+				t.Fatalf("unable to compile the condition: %v", err)
+			}
+
+			entry := &pb.CalendarEntry{
+				Title:        "1:1 Alice / Bob",
+				CalendarName: "work",
+				Attendees:    []*pb.Attendee{{Email: "room@example.com"}, {Name: "Carol", Email: "carol@example.com"}},
+			}
+
+			if !tt.condition.Matches(entry) {
+				t.Fatal("the condition doesn't match the event")
+			}
+
+			rc := RelabelConfig{Title: tt.title}
+			rc.Apply(entry, &tt.condition)
+
+			if entry.Title != tt.want {
+				t.Errorf("title %q, want %q", entry.Title, tt.want)
+			}
+		})
+	}
+}
+
+func TestRelabelRules(t *testing.T) {
+	t.Cleanup(viper.Reset)
+	viper.Set("rules", []map[string]any{
+		{
+			"name": "vacation", "key": "title", "contains": []string{"vacation"},
+			"relabelConfig": map[string]any{"title": "Out of office", "icon": "beach", "busy": "OutOfOffice", "important": true},
+		},
+		{
+			"name": "doctor", "key": "title", "contains": []string{"doctor"},
+			"relabelConfig": map[string]any{"private": true, "busy": "Busy"},
+		},
+		{
+			// the flat message and important of older configurations
+			"name": "standup", "key": "title", "contains": []string{"standup"},
+			"message": "Daily", "important": true,
+		},
+		{
+			"name": "public", "key": "title", "contains": []string{"company"},
+			"relabelConfig": map[string]any{"private": false, "message": "All hands"},
+		},
+		{"name": "invalid busy", "key": "*", "contains": []string{"*"}, "relabelConfig": map[string]any{"busy": "Away"}},
+		{"name": "everything else", "key": "*", "contains": []string{"*"}},
+	})
+
+	rules := parseRules()
+	if len(rules) != 5 {
+		t.Fatalf("got %d rules, want the invalid one to be dropped", len(rules))
+	}
+
+	tests := []struct {
+		entry *pb.CalendarEntry
+		rule  string
+		want  *pb.CalendarEntry
+	}{
+		{
+			entry: &pb.CalendarEntry{Title: "Vacation in Rome", Busy: pb.BusyState_Free},
+			rule:  "vacation",
+			want:  &pb.CalendarEntry{Title: "Out of office", Icon: "beach", Busy: pb.BusyState_OutOfOffice, Important: true},
+		},
+		{
+			entry: &pb.CalendarEntry{Title: "Doctor", Busy: pb.BusyState_Tentative},
+			rule:  "doctor",
+			want:  &pb.CalendarEntry{Title: "Doctor", Busy: pb.BusyState_Busy, Private: true},
+		},
+		{
+			entry: &pb.CalendarEntry{Title: "Standup", Busy: pb.BusyState_Busy},
+			rule:  "standup",
+			want:  &pb.CalendarEntry{Title: "Standup", Busy: pb.BusyState_Busy, Message: "Daily", Important: true},
+		},
+		{
+			entry: &pb.CalendarEntry{Title: "Company meeting", Busy: pb.BusyState_Busy, Private: true},
+			rule:  "public",
+			want:  &pb.CalendarEntry{Title: "Company meeting", Busy: pb.BusyState_Busy, Message: "All hands"},
+		},
+		{
+			entry: &pb.CalendarEntry{Title: "Lunch", Busy: pb.BusyState_Busy, Icon: "food"},
+			rule:  "everything else",
+			want:  &pb.CalendarEntry{Title: "Lunch", Busy: pb.BusyState_Busy, Icon: "food"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			rule := applyRules(tt.entry, rules)
+			if rule == nil || rule.Name != tt.rule {
+				t.Fatalf("matched rule %v, want %s", rule, tt.rule)
+			}
+
+			if !proto.Equal(tt.entry, tt.want) {
+				t.Errorf("relabelled to %v, want %v", tt.entry, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package client
+
+import (
+	"context"
+	"os"
+
+	"github.com/spf13/viper"
+
+	pb "github.com/SpechtLabs/CalendarAPI/pkg/protos"
+)
+
+// CalendarPrivacy configures how the private events of a calendar are masked
+type CalendarPrivacy struct {
+	Mask  bool   `mapstructure:"mask"`  // treats all events of the calendar as private
+	Title string `mapstructure:"title"` // title of masked events, inherits server.privacy.title if empty
+	Hide  *bool  `mapstructure:"hide"`  // leaves private events out instead of masking them, inherits server.privacy.hide if unset
+}
+
+type fullDetailsKey struct{}
+
+// WithFullDetails returns a context whose callers see private events unmasked
+func WithFullDetails(ctx context.Context) context.Context {
+	return context.WithValue(ctx, fullDetailsKey{}, true)
+}
+
+// hasFullDetails reports whether the caller may see private events unmasked
+func hasFullDetails(ctx context.Context) bool {
+	full, _ := ctx.Value(fullDetailsKey{}).(bool)
+	return full
+}
+
+type maskAllKey struct{}
+
+// WithAllMasked returns a context whose callers see all events masked, as if they were private.
+// It takes precedence over WithFullDetails.
+func WithAllMasked(ctx context.Context) context.Context {
+	return context.WithValue(ctx, maskAllKey{}, true)
+}
+
+// hasAllMasked reports whether the caller wants to see all events masked
+func hasAllMasked(ctx context.Context) bool {
+	all, _ := ctx.Value(maskAllKey{}).(bool)
+	return all
+}
+
+// calendarPrivacy returns the privacy settings by calendar name
+func calendarPrivacy() map[string]CalendarPrivacy {
+	privacy := make(map[string]CalendarPrivacy)
+	for _, cal := range parseCalendars() {
+		privacy[cal.Name] = cal.Privacy
+	}
+
+	return privacy
+}
+
+// privacyMasker returns a function that masks private events, unless the caller may see full details.
+// Callers that asked for all events to be masked get every event masked. Private events of
+// calendars that hide them are left out, the function returns nil for them.
+func privacyMasker(ctx context.Context) func(entry *pb.CalendarEntry) *pb.CalendarEntry {
+	maskAll := hasAllMasked(ctx)
+	if !maskAll && hasFullDetails(ctx) {
+		return func(entry *pb.CalendarEntry) *pb.CalendarEntry { return entry }
+	}
+
+	var privacy map[string]CalendarPrivacy
+	return func(entry *pb.CalendarEntry) *pb.CalendarEntry {
+		if entry == nil || (!entry.Private && !maskAll) {
+			return entry
+		}
+
+		// only look at the configuration once there actually is something to mask
+		if privacy == nil {
+			privacy = calendarPrivacy()
+		}
+
+		settings := privacy[entry.CalendarName]
+		if entry.Private && hidesPrivate(settings) {
+			return nil
+		}
+
+		title := settings.Title
+		if title == "" {
+			title = viper.GetString("server.privacy.title")
+		}
+
+		return maskEntry(entry, title)
+	}
+}
+
+// hidesPrivate reports whether private events of the calendar are left out instead of masked
+func hidesPrivate(settings CalendarPrivacy) bool {
+	if settings.Hide != nil {
+		return *settings.Hide
+	}
+
+	return viper.GetBool("server.privacy.hide")
+}
+
+// maskEntry returns a copy of the event that only tells when it happens. The title is replaced by
+// the template, which may refer to the event's ${calendar}, ${busy} state and ${organizer}.
+func maskEntry(entry *pb.CalendarEntry, template string) *pb.CalendarEntry {
+	return &pb.CalendarEntry{
+		Title: os.Expand(template, func(name string) string {
+			switch name {
+			case "calendar":
+				return entry.CalendarName
+			case "busy":
+				return entry.Busy.String()
+			case "organizer":
+				return organizerName(entry.Organizer)
+			default:
+				return ""
+			}
+		}),
+		Start:        entry.Start,
+		End:          entry.End,
+		AllDay:       entry.AllDay,
+		Busy:         entry.Busy,
+		Important:    entry.Important,
+		CalendarName: entry.CalendarName,
+		Private:      true,
+		Timezone:     entry.Timezone,
+	}
+}
+
+// organizerName returns the organizer's name, or their email address if they have none
+func organizerName(organizer *pb.Person) string {
+	if organizer == nil {
+		return ""
+	}
+
+	if organizer.Name != "" {
+		return organizer.Name
+	}
+
+	return organizer.Email
+}
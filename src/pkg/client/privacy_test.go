@@ -0,0 +1,134 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+
+	pb "github.com/SpechtLabs/CalendarAPI/pkg/protos"
+)
+
+func TestMaskEntryTitle(t *testing.T) {
+	tests := []struct {
+		name      string
+		organizer *pb.Person
+		template  string
+		want      string
+	}{
+		{name: "calendar and busy", template: "${busy} (${calendar})", want: "Busy (board-room)"},
+		{name: "organizer name", organizer: &pb.Person{Name: "Alice", Email: "alice@example.com"}, template: "Booked by ${organizer}", want: "Booked by Alice"},
+		{name: "organizer email", organizer: &pb.Person{Email: "alice@example.com"}, template: "Booked by ${organizer}", want: "Booked by alice@example.com"},
+		{name: "no organizer", template: "Booked by ${organizer}", want: "Booked by "},
+		{name: "unknown variable", template: "${title}", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry := &pb.CalendarEntry{
+				Title:        "Salary talk",
+				Description:  "Room 4",
+				CalendarName: "board-room",
+				Busy:         pb.BusyState_Busy,
+				Organizer:    tt.organizer,
+			}
+
+			masked := maskEntry(entry, tt.template)
+			if masked.Title != tt.want {
+				t.Errorf("title %q, want %q", masked.Title, tt.want)
+			}
+
+			if masked.Organizer != nil || masked.Description != "" || !masked.Private {
+				t.Errorf("details of the event weren't masked: %v", masked)
+			}
+		})
+	}
+}
+
+func TestPrivacyMaskerHide(t *testing.T) {
+	t.Cleanup(viper.Reset)
+	viper.Set("server.privacy.title", "Busy")
+	viper.Set("calendars", []map[string]any{
+		{"name": "inherits", "from": "file", "ical": "inherits.ics"},
+		{"name": "hides", "from": "file", "ical": "hides.ics", "privacy": map[string]any{"hide": true}},
+		{"name": "masks", "from": "file", "ical": "masks.ics", "privacy": map[string]any{"hide": false, "title": "Private"}},
+	})
+
+	tests := []struct {
+		name       string
+		serverHide bool
+		ctx        context.Context
+		calendar   string
+		private    bool
+		want       string // title the caller sees, empty if the event is hidden
+	}{
+		{name: "public event", ctx: context.Background(), calendar: "hides", want: "Standup"},
+		{name: "masked by default", ctx: context.Background(), calendar: "inherits", private: true, want: "Busy"},
+		{name: "hidden by the calendar", ctx: context.Background(), calendar: "hides", private: true},
+		{name: "hidden by the server", serverHide: true, ctx: context.Background(), calendar: "inherits", private: true},
+		{name: "calendar masks despite the server", serverHide: true, ctx: context.Background(), calendar: "masks", private: true, want: "Private"},
+		{name: "full details", serverHide: true, ctx: WithFullDetails(context.Background()), calendar: "hides", private: true, want: "Standup"},
+		{name: "all masked, public event", ctx: WithAllMasked(context.Background()), calendar: "hides", want: "Busy"},
+		{name: "all masked, private event", ctx: WithAllMasked(WithFullDetails(context.Background())), calendar: "hides", private: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Set("server.privacy.hide", tt.serverHide)
+
+			entry := &pb.CalendarEntry{Title: "Standup", CalendarName: tt.calendar, Private: tt.private}
+			masked := privacyMasker(tt.ctx)(entry)
+
+			switch {
+			case tt.want == "" && masked != nil:
+				t.Errorf("the event wasn't hidden, got %q", masked.Title)
+			case tt.want != "" && masked == nil:
+				t.Errorf("the event was hidden, want %q", tt.want)
+			case masked != nil && masked.Title != tt.want:
+				t.Errorf("title %q, want %q", masked.Title, tt.want)
+			}
+		})
+	}
+}
+
+func TestHiddenEventsAreSkipped(t *testing.T) {
+	t.Cleanup(viper.Reset)
+	viper.Set("server.privacy.hide", true)
+
+	now := time.Now().Unix()
+	e := NewICalClient(NewMemoryStatusStore())
+	e.cache = &pb.CalendarResponse{
+		From: now - 3600,
+		To:   now + 3600,
+		Entries: []*pb.CalendarEntry{
+			{Title: "Standup", CalendarName: "work", Start: now - 600, End: now + 600},
+			{Title: "Doctor", CalendarName: "work", Start: now - 60, End: now + 60, Private: true},
+			{Title: "Interview", CalendarName: "work", Start: now + 1200, End: now + 1800, Private: true, Important: true},
+			{Title: "Review", CalendarName: "work", Start: now + 1200, End: now + 1800},
+		},
+	}
+
+	ctx := context.Background()
+
+	if current := e.GetCurrentEvent(ctx, "work"); current == nil || current.Title != "Standup" {
+		t.Errorf("current event %v, want the standup", current)
+	}
+
+	if next := e.GetNextEvent(ctx, "work"); next == nil || next.Title != "Review" {
+		t.Errorf("next event %v, want the review", next)
+	}
+
+	events, err := e.GetEvents(ctx, "work", 0, 0)
+	if err != nil {
+		t.Fatal(err.Display())
+	}
+
+	if len(events.Entries) != 2 {
+		t.Errorf("got %d events, want the 2 public ones", len(events.Entries))
+	}
+
+	if current := e.GetCurrentEvent(WithFullDetails(ctx), "work"); current == nil || current.Title != "Doctor" {
+		t.Errorf("current event with full details %v, want the doctor's appointment", current)
+	}
+}
@@ -0,0 +1,106 @@
+package client
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/apognu/gocal"
+	"github.com/sierrasoftworks/humane-errors-go"
+
+	pb "github.com/SpechtLabs/CalendarAPI/pkg/protos"
+)
+
+// RuleTrace describes what the rules did to an event
+type RuleTrace struct {
+	Title   string            `json:"title" yaml:"title"`                       // the title of the event in the calendar
+	Start   int64             `json:"start" yaml:"start"`                       // the start of the event in the calendar
+	Rule    string            `json:"rule" yaml:"rule"`                         // the name of the first matching rule, empty if none matched
+	Skipped bool              `json:"skipped" yaml:"skipped"`                   // whether the event is left out of API responses
+	Reason  string            `json:"reason,omitempty" yaml:"reason,omitempty"` // why the event was skipped
+	Event   *pb.CalendarEntry `json:"event,omitempty" yaml:"event,omitempty"`   // the event as exposed by the API
+}
+
+// TraceRules runs the events of the iCal data through the configured rules, just like the server
+// does when loading the calendar calName, and reports what happened to each of them. Only events
+// within [windowStart, windowEnd) are considered; zero times default to the calendar's window.
+func TraceRules(ical io.Reader, calName string, windowStart time.Time, windowEnd time.Time) ([]RuleTrace, humane.Error) {
+	cal := ConfiguredCalendar(calName)
+	from, to := cal.Window(time.Now())
+	if windowStart.IsZero() {
+		windowStart = from
+	}
+
+	if windowEnd.IsZero() {
+		windowEnd = to
+	}
+
+	if !windowStart.Before(windowEnd) {
+		return nil, humane.New(fmt.Sprintf("invalid time range [%s, %s)", windowStart.Format(time.RFC3339), windowEnd.Format(time.RFC3339)),
+			"make sure 'from' is before 'to'",
+		)
+	}
+
+	events, err := safeIcalParse(cal, ical, windowStart, windowEnd)
+	if err != nil {
+		return nil, humane.Wrap(err, "failed to parse iCal calendar file")
+	}
+
+	rules := parseRules()
+	traces := make([]RuleTrace, 0, len(events))
+	for _, evnt := range events {
+		_, trace := evaluateEvent(cal, evnt, rules)
+		traces = append(traces, trace)
+	}
+
+	return traces, nil
+}
+
+// evaluateEvent runs the event through the rules and the privacy settings of the calendar. It
+// returns the entry to keep for the calendar, nil if the event is left out, and what happened to
+// the event. Private events of calendars that hide them are kept, since callers allowed to see
+// private events still get them, but are reported as skipped.
+func evaluateEvent(cal Calendar, evnt gocal.Event, rules []Rule) (*pb.CalendarEntry, RuleTrace) {
+	trace := RuleTrace{Title: icalText.Replace(evnt.Summary)}
+	if evnt.Start != nil {
+		trace.Start = evnt.Start.Unix()
+	}
+
+	event := NewCalendarEntryFromGocalEvent(cal, evnt)
+	if event == nil {
+		trace.Skipped = true
+		trace.Reason = "cancelled or declined"
+		return nil, trace
+	}
+
+	// only events matched by a rule other than a skip rule are kept
+	rule := applyRules(event, rules)
+	switch {
+	case rule == nil:
+		trace.Skipped = true
+		trace.Reason = "no rule matched"
+		return nil, trace
+
+	case rule.Skip:
+		trace.Rule = rule.Name
+		trace.Skipped = true
+		trace.Reason = "skip rule"
+		return nil, trace
+	}
+
+	trace.Rule = rule.Name
+
+	// masking the whole calendar wins over rules that make events public again
+	if cal.Privacy.Mask {
+		event.Private = true
+	}
+
+	if event.Private && hidesPrivate(cal.Privacy) {
+		trace.Skipped = true
+		trace.Reason = "hidden as private"
+		return event, trace
+	}
+
+	trace.Event = event
+	return event, trace
+}
@@ -0,0 +1,72 @@
+package client
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+func TestTraceRules(t *testing.T) {
+	event := func(uid string, summary string, extra ...string) []string {
+		return append([]string{
+			"BEGIN:VEVENT",
+			"UID:" + uid,
+			"DTSTAMP:20250101T000000Z",
+			"DTSTART:20250401T090000Z",
+			"DTEND:20250401T100000Z",
+			"SUMMARY:" + summary,
+		}, append(extra, "END:VEVENT")...)
+	}
+
+	lines := []string{"BEGIN:VCALENDAR", "VERSION:2.0"}
+	lines = append(lines, event("standup", "Standup")...)
+	lines = append(lines, event("sync", "Sync", "STATUS:CANCELLED")...)
+	lines = append(lines, event("lunch", "Lunch")...)
+	lines = append(lines, event("doctor", "Doctor", "CLASS:PRIVATE")...)
+	lines = append(lines, event("party", "Party")...)
+	lines = append(lines, "END:VCALENDAR")
+	ical := strings.Join(lines, "\r\n")
+
+	t.Cleanup(viper.Reset)
+	viper.Set("server.privacy.hide", true)
+	viper.Set("calendars", []map[string]any{{"name": "work", "from": "file", "ical": "work.ics"}})
+	viper.Set("rules", []map[string]any{
+		{"name": "no lunch", "key": "title", "contains": []string{"lunch"}, "skip": true},
+		{"name": "meetings", "key": "title", "contains": []string{"standup", "sync", "doctor"}},
+	})
+
+	from := time.Date(2025, time.April, 1, 0, 0, 0, 0, time.UTC)
+	traces, err := TraceRules(strings.NewReader(ical), "work", from, from.AddDate(0, 0, 1))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err.Display())
+	}
+
+	want := map[string]string{
+		"Standup": "meetings included",
+		"Sync":    " skipped (cancelled or declined)",
+		"Lunch":   "no lunch skipped (skip rule)",
+		"Doctor":  "meetings skipped (hidden as private)",
+		"Party":   " skipped (no rule matched)",
+	}
+
+	if len(traces) != len(want) {
+		t.Fatalf("got %d traces, want %d", len(traces), len(want))
+	}
+
+	for _, trace := range traces {
+		got := trace.Rule + " included"
+		if trace.Skipped {
+			got = trace.Rule + " skipped (" + trace.Reason + ")"
+		}
+
+		if got != want[trace.Title] {
+			t.Errorf("%s: %q, want %q", trace.Title, got, want[trace.Title])
+		}
+
+		if (trace.Event != nil) == trace.Skipped {
+			t.Errorf("%s: the event should only be reported for included events", trace.Title)
+		}
+	}
+}
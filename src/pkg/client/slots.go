@@ -0,0 +1,195 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sierrasoftworks/humane-errors-go"
+	"github.com/spechtlabs/go-otel-utils/otelzap"
+	"go.opentelemetry.io/otel/attribute"
+
+	pb "github.com/SpechtLabs/CalendarAPI/pkg/protos"
+)
+
+// defaultSlotLimit is the number of slots FindSlots returns unless asked for a different number
+const defaultSlotLimit = 10
+
+// FindSlots searches the cached events of the calendars (all calendars if empty) for times all of
+// them are free for the requested duration. Only events that aren't free block a calendar. The
+// search is limited to the working hours and days in the requested time zone, which defaults to
+// the server's. Every period of free time yields one slot at its start, earliest first, even if
+// it spans midnight.
+func (e *ICalClient) FindSlots(ctx context.Context, req *pb.FindSlotsRequest) (*pb.FindSlotsResponse, humane.Error) {
+	ctx, span := e.tracer.Start(ctx, "ICalClient.FindSlots")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.StringSlice("calendars", req.CalendarNames),
+		attribute.Int64("duration", req.Duration),
+	)
+
+	if req.Duration <= 0 {
+		return nil, humane.New("the duration of slots must be positive", "set 'duration' to the length of the slots you are looking for")
+	}
+
+	timezone, loc := Calendar{}.TimezoneName(), Calendar{}.Location()
+	if req.Timezone != "" {
+		var err error
+		if loc, err = loadLocation(req.Timezone); err != nil {
+			return nil, humane.Wrap(err, fmt.Sprintf("unknown timezone %s", req.Timezone), "use an IANA time zone like 'Europe/Berlin'")
+		}
+		timezone = req.Timezone
+	}
+
+	workStart, workEnd := time.Time{}, time.Time{}
+	if req.WorkingHoursStart != "" {
+		var err error
+		if workStart, err = time.Parse("15:04", req.WorkingHoursStart); err != nil {
+			return nil, humane.Wrap(err, fmt.Sprintf("invalid start of the working hours '%s'", req.WorkingHoursStart), "use a time of day like '09:00'")
+		}
+	}
+
+	if req.WorkingHoursEnd != "" {
+		var err error
+		if workEnd, err = time.Parse("15:04", req.WorkingHoursEnd); err != nil {
+			return nil, humane.Wrap(err, fmt.Sprintf("invalid end of the working hours '%s'", req.WorkingHoursEnd), "use a time of day like '17:00'")
+		}
+
+		if !workEnd.After(workStart) {
+			return nil, humane.New(fmt.Sprintf("the working hours end at %s before they start", req.WorkingHoursEnd), "make sure the working hours start before they end")
+		}
+	}
+
+	workingDays := make(map[time.Weekday]bool, len(req.WorkingDays))
+	for _, name := range req.WorkingDays {
+		day, ok := parseWeekday(name)
+		if !ok {
+			return nil, humane.New(fmt.Sprintf("unknown working day '%s'", name), "use English day names like 'Monday' or 'Mon'")
+		}
+		workingDays[day] = true
+	}
+
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultSlotLimit
+	}
+
+	if e.cache == nil {
+		otelzap.L().Ctx(ctx).Info("Experiencing cold. Fetching events now!")
+		e.FetchEvents(ctx)
+	}
+
+	e.cacheMux.RLock()
+	defer e.cacheMux.RUnlock()
+
+	// Slots in the past are of no use
+	from := req.From
+	if from == 0 {
+		from = time.Now().Unix()
+	}
+
+	from, to, err := e.cachedRange(from, req.To)
+	if err != nil {
+		return nil, err
+	}
+
+	calendars, entries, err := e.cachedEntries(req.CalendarNames)
+	if err != nil {
+		return nil, err
+	}
+
+	// The working hours of consecutive days touch if they end at midnight, they are merged so free
+	// time across midnight yields a single slot
+	type window struct{ start, end int64 }
+
+	var windows []window
+	first := time.Unix(from, 0).In(loc)
+	for day := time.Date(first.Year(), first.Month(), first.Day(), 0, 0, 0, 0, loc); day.Unix() < to; day = day.AddDate(0, 0, 1) {
+		if len(workingDays) > 0 && !workingDays[day.Weekday()] {
+			continue
+		}
+
+		start := time.Date(day.Year(), day.Month(), day.Day(), workStart.Hour(), workStart.Minute(), 0, 0, loc)
+		end := day.AddDate(0, 0, 1)
+		if !workEnd.IsZero() {
+			end = time.Date(day.Year(), day.Month(), day.Day(), workEnd.Hour(), workEnd.Minute(), 0, 0, loc)
+		}
+
+		current := window{start: max(start.Unix(), from), end: min(end.Unix(), to)}
+		if current.start >= current.end {
+			continue
+		}
+
+		if last := len(windows) - 1; last >= 0 && windows[last].end >= current.start {
+			windows[last].end = max(windows[last].end, current.end)
+			continue
+		}
+
+		windows = append(windows, current)
+	}
+
+	busy := mergeFreeBusy(entries, from, to)
+	slots := make([]*pb.Slot, 0, limit)
+	for _, w := range windows {
+		if len(slots) >= limit {
+			break
+		}
+
+		slots = append(slots, freeSlots(busy, w.start, w.end, req.Duration)...)
+	}
+
+	if len(slots) > limit {
+		slots = slots[:limit]
+	}
+
+	return &pb.FindSlotsResponse{
+		CalendarNames: calendars,
+		Duration:      req.Duration,
+		From:          from,
+		To:            to,
+		Slots:         slots,
+		Timezone:      timezone,
+	}, nil
+}
+
+// freeSlots returns a slot of the duration at the start of every period within [start, end) that
+// is free for at least the duration. busy must be sorted and non-overlapping.
+func freeSlots(busy []*pb.FreeBusyInterval, start int64, end int64, duration int64) []*pb.Slot {
+	var slots []*pb.Slot
+
+	cursor := start
+	for _, interval := range busy {
+		if interval.End <= cursor {
+			continue
+		}
+
+		if interval.Start >= end {
+			break
+		}
+
+		if interval.Start-cursor >= duration {
+			slots = append(slots, &pb.Slot{Start: cursor, End: cursor + duration, FreeUntil: interval.Start})
+		}
+
+		cursor = interval.End
+	}
+
+	if end-cursor >= duration {
+		slots = append(slots, &pb.Slot{Start: cursor, End: cursor + duration, FreeUntil: end})
+	}
+
+	return slots
+}
+
+// parseWeekday parses English day names like Monday or Mon
+func parseWeekday(name string) (time.Weekday, bool) {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if strings.EqualFold(name, day.String()) || strings.EqualFold(name, day.String()[:3]) {
+			return day, true
+		}
+	}
+
+	return 0, false
+}
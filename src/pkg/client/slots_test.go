@@ -0,0 +1,114 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	pb "github.com/SpechtLabs/CalendarAPI/pkg/protos"
+)
+
+func TestFindSlots(t *testing.T) {
+	// Tuesday, 1 April 2025
+	day := time.Date(2025, time.April, 1, 0, 0, 0, 0, time.UTC)
+	at := func(days int, hour int) int64 {
+		return day.AddDate(0, 0, days).Add(time.Duration(hour) * time.Hour).Unix()
+	}
+
+	e := NewICalClient(NewMemoryStatusStore())
+	e.cache = &pb.CalendarResponse{
+		From:      at(0, 0),
+		To:        at(4, 0),
+		Calendars: []*pb.CalendarInfo{{Name: "work"}, {Name: "room"}},
+		Entries: []*pb.CalendarEntry{
+			{CalendarName: "work", Start: at(0, 9), End: at(0, 12), Busy: pb.BusyState_Busy},
+			{CalendarName: "room", Start: at(0, 11), End: at(0, 17), Busy: pb.BusyState_Tentative},
+			{CalendarName: "work", Start: at(0, 13), End: at(0, 14), Busy: pb.BusyState_Free},
+			{CalendarName: "work", Start: at(1, 9), End: at(1, 17), Busy: pb.BusyState_Busy},
+			{CalendarName: "room", Start: at(2, 23), End: at(3, 1), Busy: pb.BusyState_Busy},
+		},
+	}
+
+	tests := []struct {
+		name string
+		req  *pb.FindSlotsRequest
+		want string // start and free until of the slots as day/hour, midnight ends the day before at 24
+	}{
+		{
+			name: "free across midnight",
+			req:  &pb.FindSlotsRequest{Duration: 3600, From: at(0, 0), To: at(3, 0)},
+			want: "0/0-0/9, 0/17-1/9, 1/17-2/23",
+		},
+		{
+			name: "limited after merging",
+			req:  &pb.FindSlotsRequest{Duration: 3600, From: at(0, 0), To: at(3, 0), Limit: 2},
+			want: "0/0-0/9, 0/17-1/9",
+		},
+		{
+			name: "from within a free period",
+			req:  &pb.FindSlotsRequest{Duration: 3600, From: at(0, 20), To: at(2, 12)},
+			want: "0/20-1/9, 1/17-2/12",
+		},
+		{
+			name: "working hours",
+			req:  &pb.FindSlotsRequest{Duration: 3600, From: at(0, 0), To: at(4, 0), WorkingHoursStart: "08:00", WorkingHoursEnd: "18:00"},
+			want: "0/8-0/9, 0/17-0/18, 1/8-1/9, 1/17-1/18, 2/8-2/18, 3/8-3/18",
+		},
+		{
+			name: "working hours until midnight",
+			req:  &pb.FindSlotsRequest{Duration: 3600, From: at(0, 0), To: at(3, 0), WorkingHoursStart: "17:00"},
+			want: "0/17-0/24, 1/17-1/24, 2/17-2/23",
+		},
+		{
+			name: "working days",
+			req:  &pb.FindSlotsRequest{Duration: 3600, From: at(0, 0), To: at(4, 0), WorkingDays: []string{"Tue", "Thursday", "fri"}},
+			want: "0/0-0/9, 0/17-0/24, 2/0-2/23, 3/1-3/24",
+		},
+		{
+			name: "only the room",
+			req:  &pb.FindSlotsRequest{CalendarNames: []string{"room"}, Duration: 3600, From: at(0, 0), To: at(2, 0)},
+			want: "0/0-0/11, 0/17-1/24",
+		},
+		{
+			name: "longer than the gaps",
+			req:  &pb.FindSlotsRequest{Duration: 10 * 3600, From: at(0, 0), To: at(3, 0)},
+			want: "0/17-1/9, 1/17-2/23",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.req.Timezone = "UTC"
+
+			slots, err := e.FindSlots(context.Background(), tt.req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err.Display())
+			}
+
+			got := make([]string, 0, len(slots.Slots))
+			for _, slot := range slots.Slots {
+				if slot.End-slot.Start != tt.req.Duration {
+					t.Errorf("slot at %d lasts %ds", slot.Start, slot.End-slot.Start)
+				}
+				got = append(got, dayHour(day, slot.Start, false)+"-"+dayHour(day, slot.FreeUntil, true))
+			}
+
+			if strings.Join(got, ", ") != tt.want {
+				t.Errorf("got slots %s, want %s", strings.Join(got, ", "), tt.want)
+			}
+		})
+	}
+}
+
+// dayHour formats the time as the number of days and hours since the day. Ends at midnight are
+// formatted as the 24th hour of the day before.
+func dayHour(day time.Time, unix int64, end bool) string {
+	hours := int(time.Unix(unix, 0).Sub(day).Hours())
+	if end && hours > 0 && hours%24 == 0 {
+		return fmt.Sprintf("%d/24", hours/24-1)
+	}
+
+	return fmt.Sprintf("%d/%d", hours/24, hours%24)
+}
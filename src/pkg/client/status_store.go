@@ -0,0 +1,218 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/sierrasoftworks/humane-errors-go"
+	"github.com/spf13/viper"
+
+	pb "github.com/SpechtLabs/CalendarAPI/pkg/protos"
+)
+
+// StatusStore keeps the custom status of every calendar
+type StatusStore interface {
+	// Get returns the custom status of the calendar, if there is one
+	Get(calendar string) (*pb.CustomStatus, bool)
+
+	// Set replaces the custom status of the calendar
+	Set(calendar string, status *pb.CustomStatus) humane.Error
+
+	// Delete removes the custom status of the calendar
+	Delete(calendar string) humane.Error
+
+	// All returns the custom statuses of all calendars by calendar name
+	All() map[string]*pb.CustomStatus
+}
+
+// NewStatusStore creates the status store configured in 'server.statusStore'
+func NewStatusStore() (StatusStore, humane.Error) {
+	storeType := viper.GetString("server.statusStore.type")
+
+	switch storeType {
+	case "", "memory":
+		return NewMemoryStatusStore(), nil
+
+	case "file":
+		return NewFileStatusStore(viper.GetString("server.statusStore.path"))
+
+	default:
+		return nil, humane.New(fmt.Sprintf("unknown status store type %s", storeType), "set 'server.statusStore.type' to either 'memory' or 'file'")
+	}
+}
+
+// MemoryStatusStore keeps custom statuses in memory only, they are lost on restart
+type MemoryStatusStore struct {
+	mux      sync.RWMutex
+	statuses map[string]*pb.CustomStatus // custom status by calendar name
+}
+
+func NewMemoryStatusStore() *MemoryStatusStore {
+	return &MemoryStatusStore{
+		statuses: make(map[string]*pb.CustomStatus),
+	}
+}
+
+func (s *MemoryStatusStore) Get(calendar string) (*pb.CustomStatus, bool) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	status, ok := s.statuses[calendar]
+	return status, ok
+}
+
+func (s *MemoryStatusStore) All() map[string]*pb.CustomStatus {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	return maps.Clone(s.statuses)
+}
+
+func (s *MemoryStatusStore) Set(calendar string, status *pb.CustomStatus) humane.Error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	s.statuses[calendar] = status
+	return nil
+}
+
+func (s *MemoryStatusStore) Delete(calendar string) humane.Error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	delete(s.statuses, calendar)
+	return nil
+}
+
+// FileStatusStore keeps custom statuses in memory and writes them to a JSON file on every
+// change, so they survive restarts
+type FileStatusStore struct {
+	mux      sync.RWMutex
+	path     string
+	statuses map[string]*pb.CustomStatus // custom status by calendar name
+}
+
+// NewFileStatusStore creates a status store backed by the JSON file at path and loads the
+// statuses already stored in it. The file is created on the first change.
+func NewFileStatusStore(path string) (*FileStatusStore, humane.Error) {
+	if path == "" {
+		return nil, humane.New("no status store path configured", "set 'server.statusStore.path' to the file statuses should be stored in")
+	}
+
+	s := &FileStatusStore{
+		path:     path,
+		statuses: make(map[string]*pb.CustomStatus),
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, humane.Wrap(err, fmt.Sprintf("unable to read status store %s", path), "check if file path exists and is accessible")
+	}
+
+	if err := json.Unmarshal(data, &s.statuses); err != nil {
+		return nil, humane.Wrap(err, fmt.Sprintf("unable to parse status store %s", path), "fix or remove the file, it is recreated on the next status change")
+	}
+
+	return s, nil
+}
+
+func (s *FileStatusStore) Get(calendar string) (*pb.CustomStatus, bool) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	status, ok := s.statuses[calendar]
+	return status, ok
+}
+
+func (s *FileStatusStore) All() map[string]*pb.CustomStatus {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	return maps.Clone(s.statuses)
+}
+
+func (s *FileStatusStore) Set(calendar string, status *pb.CustomStatus) humane.Error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	previous, existed := s.statuses[calendar]
+	s.statuses[calendar] = status
+
+	if err := s.persist(); err != nil {
+		// keep memory and file in sync
+		if existed {
+			s.statuses[calendar] = previous
+		} else {
+			delete(s.statuses, calendar)
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *FileStatusStore) Delete(calendar string) humane.Error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	previous, existed := s.statuses[calendar]
+	if !existed {
+		return nil
+	}
+
+	delete(s.statuses, calendar)
+
+	if err := s.persist(); err != nil {
+		// keep memory and file in sync
+		s.statuses[calendar] = previous
+		return err
+	}
+
+	return nil
+}
+
+// persist atomically replaces the file with the current statuses. Writing to a temporary file
+// and renaming it ensures a crash never leaves a half-written file behind.
+func (s *FileStatusStore) persist() humane.Error {
+	data, err := json.MarshalIndent(s.statuses, "", "  ")
+	if err != nil {
+		return humane.Wrap(err, "unable to encode custom statuses")
+	}
+
+	dir := filepath.Dir(s.path)
+	advice := fmt.Sprintf("make sure the directory %s exists and is writable", dir)
+
+	tmp, err := os.CreateTemp(dir, filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return humane.Wrap(err, "unable to create temporary status store file", advice)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return humane.Wrap(err, "unable to write temporary status store file", advice)
+	}
+
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return humane.Wrap(err, "unable to sync temporary status store file", advice)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return humane.Wrap(err, "unable to close temporary status store file", advice)
+	}
+
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return humane.Wrap(err, fmt.Sprintf("unable to replace status store %s", s.path), advice)
+	}
+
+	return nil
+}
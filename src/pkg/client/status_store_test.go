@@ -0,0 +1,167 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/SpechtLabs/CalendarAPI/pkg/protos"
+)
+
+func TestFileStatusStoreRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "statuses.json")
+	store, err := NewFileStatusStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the file is only created on the first change
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("the file exists before any change: %v", err)
+	}
+
+	lunch := &pb.CustomStatus{Icon: "food", IconSize: 32, Title: "Lunch", Description: "back at 1", ValidFrom: 100, ValidUntil: 200}
+	for calendar, status := range map[string]*pb.CustomStatus{"room": lunch, "desk": {Title: "Away"}} {
+		if err := store.Set(calendar, status); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := store.Delete("desk"); err != nil {
+		t.Fatal(err)
+	}
+
+	// deleting a calendar without status is fine
+	if err := store.Delete("unknown"); err != nil {
+		t.Fatal(err)
+	}
+
+	reloaded, err := NewFileStatusStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	all := reloaded.All()
+	if len(all) != 1 || !proto.Equal(all["room"], lunch) {
+		t.Errorf("reloaded %v, want only the room's status %v", all, lunch)
+	}
+
+	// no temporary files are left behind
+	if entries, _ := os.ReadDir(filepath.Dir(path)); len(entries) != 1 {
+		t.Errorf("found %d files next to the store, want only the store", len(entries))
+	}
+}
+
+func TestFileStatusStoreErrors(t *testing.T) {
+	dir := t.TempDir()
+
+	broken := filepath.Join(dir, "broken.json")
+	if err := os.WriteFile(broken, []byte("{not json"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := NewFileStatusStore(broken); err == nil {
+		t.Error("expected a broken file to be rejected")
+	}
+
+	if _, err := NewFileStatusStore(""); err == nil {
+		t.Error("expected a missing path to be rejected")
+	}
+
+	// a failing write leaves the status as it was
+	store, err := NewFileStatusStore(filepath.Join(dir, "missing", "statuses.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.Set("room", &pb.CustomStatus{Title: "Lunch"}); err == nil {
+		t.Fatal("expected writing into a missing directory to fail")
+	}
+
+	if _, ok := store.Get("room"); ok {
+		t.Error("the status was kept although it couldn't be written")
+	}
+}
+
+func TestNewStatusStore(t *testing.T) {
+	t.Cleanup(viper.Reset)
+
+	tests := []struct {
+		storeType string
+		want      string // type of the store, empty if the configuration is rejected
+	}{
+		{storeType: "", want: "*client.MemoryStatusStore"},
+		{storeType: "memory", want: "*client.MemoryStatusStore"},
+		{storeType: "file", want: "*client.FileStatusStore"},
+		{storeType: "redis"},
+	}
+
+	for _, tt := range tests {
+		viper.Set("server.statusStore.type", tt.storeType)
+		viper.Set("server.statusStore.path", filepath.Join(t.TempDir(), "statuses.json"))
+
+		store, err := NewStatusStore()
+		if tt.want == "" {
+			if err == nil {
+				t.Errorf("type %q: expected the configuration to be rejected", tt.storeType)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("type %q: unexpected error: %v", tt.storeType, err.Display())
+		}
+
+		if got := fmt.Sprintf("%T", store); got != tt.want {
+			t.Errorf("type %q: got %s, want %s", tt.storeType, got, tt.want)
+		}
+	}
+}
+
+func TestPruneExpiredStatuses(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "statuses.json")
+	store, err := NewFileStatusStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	statuses := map[string]*pb.CustomStatus{
+		"expired":   {Title: "Lunch", ValidUntil: now.Add(-time.Minute).Unix()},
+		"scheduled": {Title: "Meeting", ValidFrom: now.Add(time.Hour).Unix(), ValidUntil: now.Add(2 * time.Hour).Unix()},
+		"forever":   {Title: "Out of order"},
+	}
+	for calendar, status := range statuses {
+		if err := store.Set(calendar, status); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	e := &ICalClient{statusStore: store}
+	e.pruneExpiredStatuses(context.Background(), now)
+
+	// the file has to be pruned as well, not just the memory
+	reloaded, err := NewFileStatusStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, s := range []StatusStore{store, reloaded} {
+		if _, ok := s.Get("expired"); ok {
+			t.Error("expired status was not removed")
+		}
+
+		for _, calendar := range []string{"scheduled", "forever"} {
+			if _, ok := s.Get(calendar); !ok {
+				t.Errorf("status of %s was removed", calendar)
+			}
+		}
+	}
+}
@@ -0,0 +1,445 @@
+package client
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+	_ "time/tzdata" // the container image ships without a time zone database
+
+	"github.com/apognu/gocal"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/spechtlabs/go-otel-utils/otelzap"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+// TimezoneOverride maps a TZID used in iCal files to a time zone
+type TimezoneOverride struct {
+	TZID     string `mapstructure:"tzid"`     // the TZID as it appears in the iCal file
+	Location string `mapstructure:"location"` // the IANA time zone to use for it, e.g. Europe/Berlin
+}
+
+var unknownTimezones = promauto.NewCounterVec(prometheus.CounterOpts{
+	Subsystem: "conf_room_display",
+	Name:      "unknown_tzid_total",
+	Help:      "Number of iCal files referring to a TZID that could not be resolved, events using it are interpreted as UTC",
+}, []string{"calendar", "tzid"})
+
+// locationCache holds the loaded time zones by name, loading them is comparatively expensive
+var locationCache sync.Map
+
+// loadLocation is time.LoadLocation, caching the loaded time zones
+func loadLocation(name string) (*time.Location, error) {
+	if loc, ok := locationCache.Load(name); ok {
+		return loc.(*time.Location), nil
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, err
+	}
+
+	locationCache.Store(name, loc)
+	return loc, nil
+}
+
+// floatingTZID is the TZID times without a time zone are tagged with, so they are interpreted in
+// the calendar's time zone rather than the server's
+const floatingTZID = "X-CALENDARAPI-FLOATING"
+
+// floatingTime matches start, end and exception dates with a local time (neither UTC nor a
+// TZID) at the start of a (unfolded) content line
+var floatingTime = regexp.MustCompile(`(?m)^((?:DTSTART|DTEND|EXDATE)(?:;[^:\r\n]*)?):(\d{8}T\d{6}(?:,\d{8}T\d{6})*\r?)$`)
+
+// tagFloatingTimes adds the floatingTZID to all floating times of the iCal data
+func tagFloatingTimes(raw []byte) []byte {
+	return floatingTime.ReplaceAllFunc(raw, func(line []byte) []byte {
+		name, value, _ := bytes.Cut(line, []byte(":"))
+		if bytes.Contains(bytes.ToUpper(name), []byte(";TZID=")) {
+			return line
+		}
+
+		return slices.Concat(name, []byte(";TZID="+floatingTZID+":"), value)
+	})
+}
+
+// gocal only supports a single, global TZ mapper, which doesn't know which file a TZID belongs to.
+// So every parse registers its own resolver and the TZIDs of the file are prefixed with the
+// resolver's ID, which lets the mapper find the resolver. Files can thus be parsed concurrently.
+var (
+	resolverIDs atomic.Uint64
+	resolvers   sync.Map // resolver ID -> *timezoneResolver
+)
+
+// resolverTZIDPrefix is the prefix of TZIDs tagged with the ID of their resolver
+const resolverTZIDPrefix = "X-CALENDARAPI-TZ-"
+
+// tzidParam matches the TZID parameter of a (unfolded) content line and an opening quote
+var tzidParam = regexp.MustCompile(`(?mi)^([A-Z0-9-]+(?:;[^:"\r\n]*)?;TZID=)("?)`)
+
+func init() {
+	gocal.SetTZMapper(func(tzid string) (*time.Location, error) {
+		id, tzid, ok := strings.Cut(strings.TrimPrefix(strings.Trim(tzid, `"`), resolverTZIDPrefix), "~")
+		if !ok {
+			return nil, fmt.Errorf("unknown time zone %s", tzid)
+		}
+
+		resolver, _ := resolvers.Load(id)
+		r, _ := resolver.(*timezoneResolver)
+		return r.resolve(tzid)
+	})
+}
+
+// timezoneResolver resolves the TZIDs of a single iCal file
+type timezoneResolver struct {
+	calendar  string
+	floating  *time.Location // the time zone of floating times
+	overrides map[string]string
+	embedded  map[string]*time.Location // the time zones defined by the file's VTIMEZONE components
+	unknown   map[string]bool
+}
+
+// withTimezones runs parse on the iCal data raw of the calendar, with its TZIDs tagged so gocal
+// resolves them against the calendar and the data's VTIMEZONE components
+func withTimezones(cal Calendar, raw []byte, parse func(tagged []byte)) {
+	id := strconv.FormatUint(resolverIDs.Add(1), 10)
+	resolvers.Store(id, &timezoneResolver{
+		calendar:  cal.Name,
+		floating:  cal.Location(),
+		overrides: timezoneOverrides(),
+		embedded:  parseVTimezones(raw),
+		unknown:   map[string]bool{},
+	})
+	defer resolvers.Delete(id)
+
+	parse(tzidParam.ReplaceAll(raw, []byte("${1}${2}"+resolverTZIDPrefix+id+"~")))
+}
+
+// customizedTZID is the TZID Outlook uses for time zones it doesn't have a name for. It usually
+// defines it in a VTIMEZONE component, files without one have always been read as UTC.
+const customizedTZID = "Customized Time Zone"
+
+// resolve looks up the TZID in the configured overrides, the IANA time zone database, the
+// VTIMEZONE components of the file and the Windows time zone names, in that order. The file's
+// own definition wins over the Windows names, since Exchange may customize those.
+func (r *timezoneResolver) resolve(tzid string) (*time.Location, error) {
+	if r == nil {
+		return nil, fmt.Errorf("unknown time zone %s", tzid)
+	}
+
+	tzid = strings.Trim(tzid, `"`)
+
+	if tzid == floatingTZID {
+		return r.floating, nil
+	}
+
+	if location, ok := r.overrides[tzid]; ok {
+		return loadLocation(location)
+	}
+
+	if loc, err := loadLocation(tzid); err == nil && tzid != "" && tzid != "Local" {
+		return loc, nil
+	}
+
+	if loc, ok := r.embedded[tzid]; ok {
+		return loc, nil
+	}
+
+	if location, ok := windowsZones[tzid]; ok {
+		return loadLocation(location)
+	}
+
+	if tzid == customizedTZID {
+		return time.UTC, nil
+	}
+
+	// gocal asks for every date referring to the TZID, only report it once per file
+	if !r.unknown[tzid] {
+		r.unknown[tzid] = true
+		unknownTimezones.WithLabelValues(r.calendar, tzid).Inc()
+		otelzap.L().Warn("Unknown time zone, interpreting times as UTC",
+			zap.String("calendar", r.calendar),
+			zap.String("tzid", tzid),
+		)
+	}
+
+	return nil, fmt.Errorf("unknown time zone %s", tzid)
+}
+
+func unmarshalTimezoneOverrides(v *viper.Viper) ([]TimezoneOverride, error) {
+	var overrides []TimezoneOverride
+	err := v.UnmarshalKey("timezones", &overrides)
+	return overrides, err
+}
+
+// timezoneOverrides returns the configured time zones by TZID
+func timezoneOverrides() map[string]string {
+	overrides, err := unmarshalTimezoneOverrides(viper.GetViper())
+	if err != nil {
+		otelzap.L().WithError(err).Error("Failed to parse timezones")
+	}
+
+	byTZID := make(map[string]string, len(overrides))
+	for _, override := range overrides {
+		byTZID[override.TZID] = override.Location
+	}
+
+	return byTZID
+}
+
+// observance is a STANDARD or DAYLIGHT component of a VTIMEZONE
+type observance struct {
+	daylight bool
+	start    string            // DTSTART in local time, e.g. 16010101T030000
+	offset   int               // TZOFFSETTO in seconds east of UTC
+	rrule    map[string]string // the parts of the RRULE, e.g. BYMONTH: 10
+}
+
+// parseVTimezones returns the time zones defined by the VTIMEZONE components of the iCal data by TZID
+func parseVTimezones(raw []byte) map[string]*time.Location {
+	locations := map[string]*time.Location{}
+	if !bytes.Contains(raw, []byte("BEGIN:VTIMEZONE")) {
+		return locations
+	}
+
+	// unfold the content lines, continuation lines start with a space or a tab
+	text := strings.ReplaceAll(string(raw), "\r\n", "\n")
+	text = strings.NewReplacer("\n ", "", "\n\t", "").Replace(text)
+
+	inTimezone := false
+	tzid := ""
+	var observances []observance
+	var current *observance
+
+	for _, line := range strings.Split(text, "\n") {
+		name, value := splitContentLine(line)
+
+		switch {
+		case name == "BEGIN" && value == "VTIMEZONE":
+			inTimezone, tzid, observances = true, "", nil
+
+		case !inTimezone:
+
+		case name == "END" && value == "VTIMEZONE":
+			inTimezone = false
+			if loc := vtimezoneLocation(tzid, observances); tzid != "" && loc != nil {
+				locations[tzid] = loc
+			}
+
+		case name == "BEGIN" && (value == "STANDARD" || value == "DAYLIGHT"):
+			current = &observance{daylight: value == "DAYLIGHT", offset: -1}
+
+		case current == nil:
+			if name == "TZID" {
+				tzid = value
+			}
+
+		case name == "END":
+			if current.offset != -1 {
+				observances = append(observances, *current)
+			}
+			current = nil
+
+		case name == "DTSTART":
+			current.start = value
+
+		case name == "TZOFFSETTO":
+			if offset, ok := parseUTCOffset(value); ok {
+				current.offset = offset
+			}
+
+		case name == "RRULE":
+			current.rrule = map[string]string{}
+			for _, part := range strings.Split(value, ";") {
+				if key, val, ok := strings.Cut(part, "="); ok {
+					current.rrule[strings.ToUpper(key)] = strings.ToUpper(val)
+				}
+			}
+		}
+	}
+
+	return locations
+}
+
+// splitContentLine splits an iCal content line into its upper case property name and its value
+func splitContentLine(line string) (string, string) {
+	line = strings.TrimRight(line, "\r")
+
+	nameAndParams, value, ok := strings.Cut(line, ":")
+	if !ok {
+		return "", ""
+	}
+
+	name, _, _ := strings.Cut(nameAndParams, ";")
+	return strings.ToUpper(strings.TrimSpace(name)), strings.TrimSpace(value)
+}
+
+// parseUTCOffset parses an iCal UTC offset like +0100, -0530 or +013045 into seconds east of UTC
+func parseUTCOffset(value string) (int, bool) {
+	if len(value) != 5 && len(value) != 7 {
+		return 0, false
+	}
+
+	sign := 1
+	switch value[0] {
+	case '+':
+	case '-':
+		sign = -1
+	default:
+		return 0, false
+	}
+
+	offset := 0
+	for i, unit := range []int{3600, 60, 1} {
+		if 1+2*i >= len(value) {
+			break
+		}
+
+		n, err := strconv.Atoi(value[1+2*i : 3+2*i])
+		if err != nil {
+			return 0, false
+		}
+		offset += n * unit
+	}
+
+	return sign * offset, true
+}
+
+// vtimezoneLocation builds the time zone described by the observances of a VTIMEZONE. Only the
+// latest STANDARD and DAYLIGHT observances are taken into account, so times before the last
+// change of the rules might be off.
+func vtimezoneLocation(tzid string, observances []observance) *time.Location {
+	var std, dst *observance
+	for i := range observances {
+		o := &observances[i]
+		if o.daylight && (dst == nil || o.start >= dst.start) {
+			dst = o
+		} else if !o.daylight && (std == nil || o.start >= std.start) {
+			std = o
+		}
+	}
+
+	if std == nil {
+		std, dst = dst, nil
+	}
+
+	if std == nil {
+		return nil
+	}
+
+	if dst != nil {
+		dstRule, dstOK := posixRule(dst)
+		stdRule, stdOK := posixRule(std)
+		if dstOK && stdOK {
+			tz := fmt.Sprintf("%s%s%s%s,%s,%s",
+				posixName(std.offset), posixOffset(std.offset),
+				posixName(dst.offset), posixOffset(dst.offset),
+				dstRule, stdRule,
+			)
+
+			if loc, err := locationFromPOSIX(tzid, tz); err == nil {
+				return loc
+			}
+		}
+
+		// without recurring changes, whatever observance started last is in effect
+		if dst.start > std.start {
+			std = dst
+		}
+	}
+
+	return time.FixedZone(tzid, std.offset)
+}
+
+// posixRule converts the yearly recurrence of the observance into the POSIX TZ rule Mm.w.d/time
+func posixRule(o *observance) (string, bool) {
+	if o.rrule["FREQ"] != "YEARLY" || o.rrule["UNTIL"] != "" || o.rrule["COUNT"] != "" || o.rrule["BYMONTHDAY"] != "" {
+		return "", false
+	}
+
+	month, err := strconv.Atoi(o.rrule["BYMONTH"])
+	if err != nil || month < 1 || month > 12 {
+		return "", false
+	}
+
+	byDay := o.rrule["BYDAY"]
+	if len(byDay) < 3 {
+		return "", false
+	}
+
+	day := strings.Index("SUMOTUWETHFRSA", byDay[len(byDay)-2:])
+	nth, err := strconv.Atoi(byDay[:len(byDay)-2])
+	if day < 0 || day%2 != 0 || err != nil {
+		return "", false
+	}
+
+	switch {
+	case nth == -1:
+		nth = 5 // POSIX's 5th week is the last one
+	case nth < 1 || nth > 5:
+		return "", false
+	}
+
+	// DTSTART is in the local time before the change, just like the time of POSIX rules
+	at := "02:00:00"
+	if _, clock, ok := strings.Cut(o.start, "T"); ok && len(clock) >= 6 {
+		at = fmt.Sprintf("%s:%s:%s", clock[0:2], clock[2:4], clock[4:6])
+	}
+
+	return fmt.Sprintf("M%d.%d.%d/%s", month, nth, day/2, at), true
+}
+
+// posixName returns a quoted POSIX TZ abbreviation for the offset, e.g. <+0100>
+func posixName(offset int) string {
+	sign := '+'
+	if offset < 0 {
+		sign, offset = '-', -offset
+	}
+
+	return fmt.Sprintf("<%c%02d%02d>", sign, offset/3600, offset/60%60)
+}
+
+// posixOffset returns the offset in POSIX TZ notation, which counts hours west of UTC
+func posixOffset(offset int) string {
+	sign := "-"
+	if offset <= 0 {
+		sign, offset = "", -offset
+	}
+
+	return fmt.Sprintf("%s%d:%02d:%02d", sign, offset/3600, offset/60%60, offset%60)
+}
+
+// locationFromPOSIX creates a time zone following the POSIX TZ rule. Go only loads such rules as
+// part of a TZif file, so we wrap it in one without any transitions.
+func locationFromPOSIX(name string, tz string) (*time.Location, error) {
+	var data bytes.Buffer
+
+	header := func(typeCount uint32, charCount uint32) {
+		data.WriteString("TZif2")
+		data.Write(make([]byte, 15))
+		// isutcnt, isstdcnt, leapcnt, timecnt, typecnt, charcnt
+		for _, n := range []uint32{0, 0, 0, 0, typeCount, charCount} {
+			_ = binary.Write(&data, binary.BigEndian, n)
+		}
+	}
+
+	// the version 1 data is skipped by Go, leave it empty
+	header(0, 0)
+
+	// a single local time type, which is only used before the rule takes effect
+	header(1, 1)
+	data.Write([]byte{0, 0, 0, 0, 0, 0}) // utoff, isdst, desigidx
+	data.WriteByte(0)                    // an empty abbreviation
+
+	data.WriteString("\n" + tz + "\n")
+
+	return time.LoadLocationFromTZData(name, data.Bytes())
+}
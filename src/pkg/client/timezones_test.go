@@ -0,0 +1,401 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+func TestParseVTimezones(t *testing.T) {
+	type instant struct {
+		local string // DTSTART in the time zone
+		utc   string // the instant it refers to
+	}
+
+	tests := []struct {
+		name      string
+		tzid      string
+		vtimezone []string
+		instants  []instant
+	}{
+		{
+			// as exported by Outlook, with observances starting in 1601
+			name: "Europe/Berlin",
+			tzid: "W. Europe Standard Time (custom)",
+			vtimezone: []string{
+				"BEGIN:STANDARD",
+				"DTSTART:16010101T030000",
+				"TZOFFSETFROM:+0200",
+				"TZOFFSETTO:+0100",
+				"RRULE:FREQ=YEARLY;BYDAY=-1SU;BYMONTH=10",
+				"END:STANDARD",
+				"BEGIN:DAYLIGHT",
+				"DTSTART:16010101T020000",
+				"TZOFFSETFROM:+0100",
+				"TZOFFSETTO:+0200",
+				"RRULE:FREQ=YEARLY;BYDAY=-1SU;BYMONTH=3",
+				"END:DAYLIGHT",
+			},
+			instants: []instant{
+				{"20250330T013000", "2025-03-30T00:30:00Z"}, // before the change to summer time
+				{"20250330T033000", "2025-03-30T01:30:00Z"}, // after it
+				{"20250715T120000", "2025-07-15T10:00:00Z"},
+				{"20251026T013000", "2025-10-25T23:30:00Z"}, // before the change to winter time
+				{"20251026T033000", "2025-10-26T02:30:00Z"}, // after it
+				{"20251215T120000", "2025-12-15T11:00:00Z"},
+			},
+		},
+		{
+			// summer time spans the turn of the year
+			name: "Australia/Sydney",
+			tzid: "Custom Sydney",
+			vtimezone: []string{
+				"BEGIN:STANDARD",
+				"DTSTART:20080406T030000",
+				"TZOFFSETFROM:+1100",
+				"TZOFFSETTO:+1000",
+				"RRULE:FREQ=YEARLY;BYMONTH=4;BYDAY=1SU",
+				"END:STANDARD",
+				"BEGIN:DAYLIGHT",
+				"DTSTART:20081005T020000",
+				"TZOFFSETFROM:+1000",
+				"TZOFFSETTO:+1100",
+				"RRULE:FREQ=YEARLY;BYMONTH=10;BYDAY=1SU",
+				"END:DAYLIGHT",
+			},
+			instants: []instant{
+				{"20250115T120000", "2025-01-15T01:00:00Z"},
+				{"20250406T013000", "2025-04-05T14:30:00Z"}, // before the end of summer time
+				{"20250406T033000", "2025-04-05T17:30:00Z"}, // after it
+				{"20250715T120000", "2025-07-15T02:00:00Z"},
+				{"20251005T013000", "2025-10-04T15:30:00Z"}, // before the start of summer time
+				{"20251005T033000", "2025-10-04T16:30:00Z"}, // after it
+			},
+		},
+		{
+			name: "fixed offset",
+			tzid: "India (custom)",
+			vtimezone: []string{
+				"BEGIN:STANDARD",
+				"DTSTART:16010101T000000",
+				"TZOFFSETFROM:+0530",
+				"TZOFFSETTO:+0530",
+				"END:STANDARD",
+			},
+			instants: []instant{
+				{"20250330T013000", "2025-03-29T20:00:00Z"},
+				{"20250715T120000", "2025-07-15T06:30:00Z"},
+				{"20251026T033000", "2025-10-25T22:00:00Z"},
+			},
+		},
+		{
+			// the rules changed without recurring transitions, the latest observance applies
+			name: "abolished summer time",
+			tzid: "Custom Istanbul",
+			vtimezone: []string{
+				"BEGIN:STANDARD",
+				"DTSTART:20151025T040000",
+				"TZOFFSETFROM:+0300",
+				"TZOFFSETTO:+0200",
+				"END:STANDARD",
+				"BEGIN:STANDARD",
+				"DTSTART:20160907T000000",
+				"TZOFFSETFROM:+0300",
+				"TZOFFSETTO:+0300",
+				"END:STANDARD",
+				"BEGIN:DAYLIGHT",
+				"DTSTART:20160327T030000",
+				"TZOFFSETFROM:+0200",
+				"TZOFFSETTO:+0300",
+				"END:DAYLIGHT",
+			},
+			instants: []instant{
+				{"20250115T120000", "2025-01-15T09:00:00Z"},
+				{"20250715T120000", "2025-07-15T09:00:00Z"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines := []string{"BEGIN:VCALENDAR", "VERSION:2.0", "BEGIN:VTIMEZONE", "TZID:" + tt.tzid}
+			lines = append(lines, tt.vtimezone...)
+			lines = append(lines, "END:VTIMEZONE")
+			for i, instant := range tt.instants {
+				lines = append(lines,
+					"BEGIN:VEVENT",
+					fmt.Sprintf("UID:%d@example.com", i),
+					"DTSTAMP:20250101T000000Z",
+					fmt.Sprintf("DTSTART;TZID=%s:%s", tt.tzid, instant.local),
+					"DURATION:PT15M",
+					"SUMMARY:"+instant.local,
+					"END:VEVENT",
+				)
+			}
+			lines = append(lines, "END:VCALENDAR")
+			raw := strings.Join(lines, "\r\n")
+
+			loc, ok := parseVTimezones([]byte(raw))[tt.tzid]
+			if !ok {
+				t.Fatalf("time zone %s wasn't parsed", tt.tzid)
+			}
+
+			for _, instant := range tt.instants {
+				local, err := time.ParseInLocation("20060102T150405", instant.local, loc)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if got := local.UTC().Format(time.RFC3339); got != instant.utc {
+					t.Errorf("%s is %s, want %s", instant.local, got, instant.utc)
+				}
+			}
+
+			// the events referring to the time zone are resolved the same way
+			start := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+			events, herr := safeIcalParse(Calendar{Name: "test", Timezone: "UTC"}, strings.NewReader(raw), start, start.AddDate(1, 0, 0))
+			if herr != nil {
+				t.Fatalf("unable to parse the calendar: %v", herr.Display())
+			}
+
+			if len(events) != len(tt.instants) {
+				t.Fatalf("parsed %d events, want %d", len(events), len(tt.instants))
+			}
+
+			for _, event := range events {
+				for _, instant := range tt.instants {
+					if event.Summary != instant.local {
+						continue
+					}
+
+					if got := event.Start.UTC().Format(time.RFC3339); got != instant.utc {
+						t.Errorf("event at %s starts %s, want %s", instant.local, got, instant.utc)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestTimezoneResolverOrder(t *testing.T) {
+	india := time.FixedZone("India (custom)", 5*60*60+30*60)
+	r := &timezoneResolver{
+		overrides: map[string]string{"Overridden": "America/New_York", "Customized Time Zone": "Asia/Tokyo"},
+		embedded:  map[string]*time.Location{"W. Europe Standard Time": india, "Overridden": india},
+		unknown:   map[string]bool{},
+	}
+
+	tests := []struct {
+		tzid string
+		want string // the offset at the start of 2025
+	}{
+		{tzid: "Overridden", want: "-05:00"},
+		{tzid: "W. Europe Standard Time", want: "+05:30"},
+		{tzid: "Romance Standard Time", want: "+01:00"},
+		{tzid: "Customized Time Zone", want: "+09:00"},
+	}
+
+	at := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	for _, tt := range tests {
+		loc, err := r.resolve(tt.tzid)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.tzid, err)
+		}
+
+		if got := at.In(loc).Format("-07:00"); got != tt.want {
+			t.Errorf("%s resolved to %s, want %s", tt.tzid, got, tt.want)
+		}
+	}
+
+	// without a definition, Outlook's customized time zone is UTC
+	r.overrides = nil
+	if loc, err := r.resolve("Customized Time Zone"); err != nil || loc != time.UTC {
+		t.Errorf("Customized Time Zone resolved to %v (%v), want UTC", loc, err)
+	}
+}
+
+func TestConcurrentParsesResolveTheirOwnTimezones(t *testing.T) {
+	// two files define the same TZID with different offsets
+	calendar := func(offset string) string {
+		return strings.Join([]string{
+			"BEGIN:VCALENDAR",
+			"VERSION:2.0",
+			"BEGIN:VTIMEZONE",
+			"TZID:Office",
+			"BEGIN:STANDARD",
+			"DTSTART:19700101T000000",
+			"TZOFFSETFROM:" + offset,
+			"TZOFFSETTO:" + offset,
+			"END:STANDARD",
+			"END:VTIMEZONE",
+			"BEGIN:VEVENT",
+			"UID:meeting@example.com",
+			"DTSTAMP:20250101T000000Z",
+			`DTSTART;TZID="Office":20250401T120000`,
+			"DURATION:PT1H",
+			"SUMMARY:Meeting",
+			"END:VEVENT",
+			"END:VCALENDAR",
+		}, "\r\n")
+	}
+
+	start := time.Date(2025, time.April, 1, 0, 0, 0, 0, time.UTC)
+	want := map[string]string{"+0200": "2025-04-01T10:00:00Z", "-0500": "2025-04-01T17:00:00Z"}
+
+	var wg sync.WaitGroup
+	for offset, utc := range want {
+		for range 20 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				events, err := safeIcalParse(Calendar{Name: offset, Timezone: "UTC"}, strings.NewReader(calendar(offset)), start, start.AddDate(0, 0, 1))
+				if err != nil || len(events) != 1 {
+					t.Errorf("unable to parse the calendar with offset %s: %v", offset, err)
+					return
+				}
+
+				if got := events[0].Start.UTC().Format(time.RFC3339); got != utc {
+					t.Errorf("event with offset %s starts %s, want %s", offset, got, utc)
+				}
+			}()
+		}
+	}
+
+	wg.Wait()
+}
+
+func TestLocationFromPOSIX(t *testing.T) {
+	tests := []struct {
+		tz   string
+		time time.Time
+		want string
+	}{
+		{tz: "<+0100>-1<+0200>-2,M3.5.0/02:00:00,M10.5.0/03:00:00", time: time.Date(2025, time.February, 1, 12, 0, 0, 0, time.UTC), want: "+0100"},
+		{tz: "<+0100>-1<+0200>-2,M3.5.0/02:00:00,M10.5.0/03:00:00", time: time.Date(2025, time.August, 1, 12, 0, 0, 0, time.UTC), want: "+0200"},
+		{tz: "<+1000>-10<+1100>-11,M10.1.0/02:00:00,M4.1.0/03:00:00", time: time.Date(2025, time.February, 1, 12, 0, 0, 0, time.UTC), want: "+1100"},
+		{tz: "<+1000>-10<+1100>-11,M10.1.0/02:00:00,M4.1.0/03:00:00", time: time.Date(2025, time.August, 1, 12, 0, 0, 0, time.UTC), want: "+1000"},
+		{tz: "<-0330>3:30", time: time.Date(2025, time.August, 1, 12, 0, 0, 0, time.UTC), want: "-0330"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tz+" "+tt.time.Format("Jan"), func(t *testing.T) {
+			loc, err := locationFromPOSIX("test", tt.tz)
+			if err != nil {
+				t.Fatalf("unable to load %s: %v", tt.tz, err)
+			}
+
+			if got := tt.time.In(loc).Format("-0700"); got != tt.want {
+				t.Errorf("offset %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDisplayTimezones(t *testing.T) {
+	dir := t.TempDir()
+
+	// a floating event at 09:00 in the calendar's time zone, tomorrow
+	tomorrow := time.Now().In(mustLoadLocation(t, "Asia/Tokyo")).AddDate(0, 0, 1)
+	ical := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"BEGIN:VEVENT",
+		"UID:floating@example.com",
+		"DTSTAMP:20250101T000000Z",
+		"DTSTART:" + tomorrow.Format("20060102") + "T090000",
+		"DURATION:PT1H",
+		"SUMMARY:Breakfast",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+
+	for _, name := range []string{"tokyo.ics", "server.ics"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(ical), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Cleanup(viper.Reset)
+	viper.Set("server.timezone", "Europe/Berlin")
+	viper.Set("server.lookAhead", 72*time.Hour)
+	viper.Set("rules", []map[string]any{{"name": "all", "key": "*", "contains": []string{"*"}}})
+	viper.Set("calendars", []map[string]any{
+		{"name": "tokyo", "from": "file", "ical": filepath.Join(dir, "tokyo.ics"), "timezone": "Asia/Tokyo"},
+		{"name": "server", "from": "file", "ical": filepath.Join(dir, "server.ics")},
+	})
+
+	e := NewICalClient(NewMemoryStatusStore())
+	e.FetchEvents(context.Background())
+
+	tests := []struct {
+		calendar string
+		timezone string
+		events   int
+	}{
+		{calendar: "all", timezone: "Europe/Berlin", events: 2},
+		{calendar: "tokyo", timezone: "Asia/Tokyo", events: 1},
+		{calendar: "server", timezone: "Europe/Berlin", events: 1},
+	}
+
+	for _, tt := range tests {
+		events, err := e.GetEvents(context.Background(), tt.calendar, 0, 0)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err.Display())
+		}
+
+		if events.Timezone != tt.timezone || len(events.Entries) != tt.events {
+			t.Errorf("%s has %d events displayed in %q, want %d in %q", tt.calendar, len(events.Entries), events.Timezone, tt.events, tt.timezone)
+		}
+
+		for _, entry := range events.Entries {
+			loc := mustLoadLocation(t, entry.Timezone)
+			if start := time.Unix(entry.Start, 0).In(loc); start.Hour() != 9 {
+				t.Errorf("event of %s starts at %s, want 09:00 in %s", entry.CalendarName, start.Format(time.Kitchen), entry.Timezone)
+			}
+		}
+	}
+
+	if availability := e.GetAvailability(context.Background(), "tokyo"); availability.Timezone != "Asia/Tokyo" {
+		t.Errorf("availability of tokyo is displayed in %q, want Asia/Tokyo", availability.Timezone)
+	}
+}
+
+func mustLoadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return loc
+}
+
+func TestValidateConfigTimezones(t *testing.T) {
+	v := viper.New()
+	v.Set("server.timezone", "Mars/Olympus_Mons")
+	v.Set("calendars", []map[string]any{
+		{"name": "work", "from": "file", "ical": "work.ics", "timezone": "Europe/Berlin"},
+		{"name": "moon", "from": "file", "ical": "moon.ics", "timezone": "Moon/Tranquility"},
+	})
+
+	errs, _ := ValidateConfig(v)
+
+	var got []string
+	for _, err := range errs {
+		got = append(got, err.Error())
+	}
+
+	want := []string{"calendar moon has an unknown timezone", "'server.timezone' is not a known time zone"}
+	if strings.Join(got, ", ") != strings.Join(want, ", ") {
+		t.Errorf("got errors %v, want %v", got, want)
+	}
+}
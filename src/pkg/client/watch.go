@@ -0,0 +1,64 @@
+package client
+
+import (
+	"time"
+)
+
+// Changed returns a channel that is closed as soon as the cached events or a custom status change.
+// Callers have to fetch a new channel after every change.
+func (e *ICalClient) Changed() <-chan struct{} {
+	e.changedMux.Lock()
+	defer e.changedMux.Unlock()
+
+	return e.changed
+}
+
+// notifyChanged wakes up everyone waiting on Changed
+func (e *ICalClient) notifyChanged() {
+	e.changedMux.Lock()
+	defer e.changedMux.Unlock()
+
+	close(e.changed)
+	e.changed = make(chan struct{})
+}
+
+// NextBoundary returns the next time after now at which an event of the given calendar ("all"
+// for every calendar) starts or ends or the calendar's custom status becomes valid or expires,
+// i.e. when the current event or custom status might change. It returns the zero time if there
+// is no such boundary within the cached window.
+func (e *ICalClient) NextBoundary(calendar string, now time.Time) time.Time {
+	e.cacheMux.RLock()
+	defer e.cacheMux.RUnlock()
+
+	next := int64(0)
+	consider := func(boundary int64) {
+		if boundary > now.Unix() && (next == 0 || boundary < next) {
+			next = boundary
+		}
+	}
+
+	for _, entry := range e.cache.Entries {
+		if calendar != "all" && entry.CalendarName != calendar {
+			continue
+		}
+
+		// Events only count as current strictly after their start, so that boundary passes a second later
+		consider(entry.Start + 1)
+		consider(entry.End)
+	}
+
+	if status, ok := e.statusStore.Get(calendar); ok {
+		if status.ValidFrom != 0 {
+			consider(status.ValidFrom)
+		}
+		if status.ValidUntil != 0 {
+			consider(status.ValidUntil)
+		}
+	}
+
+	if next == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(next, 0)
+}
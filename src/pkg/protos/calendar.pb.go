@@ -80,14 +80,25 @@ type CalendarEntry struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Title        string    `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
-	Start        int64     `protobuf:"varint,2,opt,name=start,proto3" json:"start,omitempty"`
-	End          int64     `protobuf:"varint,3,opt,name=end,proto3" json:"end,omitempty"`
-	AllDay       bool      `protobuf:"varint,4,opt,name=all_day,json=allDay,proto3" json:"all_day,omitempty"`
-	Busy         BusyState `protobuf:"varint,5,opt,name=busy,proto3,enum=meetingroom_display_epd.BusyState" json:"busy,omitempty"`
-	Important    bool      `protobuf:"varint,6,opt,name=important,proto3" json:"important,omitempty"`
-	Message      string    `protobuf:"bytes,7,opt,name=message,proto3" json:"message,omitempty"`
-	CalendarName string    `protobuf:"bytes,8,opt,name=calendar_name,json=calendarName,proto3" json:"calendar_name,omitempty"`
+	Title        string      `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Start        int64       `protobuf:"varint,2,opt,name=start,proto3" json:"start,omitempty"`
+	End          int64       `protobuf:"varint,3,opt,name=end,proto3" json:"end,omitempty"`
+	AllDay       bool        `protobuf:"varint,4,opt,name=all_day,json=allDay,proto3" json:"all_day,omitempty"`
+	Busy         BusyState   `protobuf:"varint,5,opt,name=busy,proto3,enum=meetingroom_display_epd.BusyState" json:"busy,omitempty"`
+	Important    bool        `protobuf:"varint,6,opt,name=important,proto3" json:"important,omitempty"`
+	Message      string      `protobuf:"bytes,7,opt,name=message,proto3" json:"message,omitempty"`
+	CalendarName string      `protobuf:"bytes,8,opt,name=calendar_name,json=calendarName,proto3" json:"calendar_name,omitempty"`
+	Icon         string      `protobuf:"bytes,9,opt,name=icon,proto3" json:"icon,omitempty"`
+	Private      bool        `protobuf:"varint,10,opt,name=private,proto3" json:"private,omitempty"`
+	Location     string      `protobuf:"bytes,11,opt,name=location,proto3" json:"location,omitempty"`
+	Organizer    *Person     `protobuf:"bytes,12,opt,name=organizer,proto3" json:"organizer,omitempty"`
+	Attendees    []*Attendee `protobuf:"bytes,13,rep,name=attendees,proto3" json:"attendees,omitempty"`
+	Description  string      `protobuf:"bytes,14,opt,name=description,proto3" json:"description,omitempty"`
+	Uid          string      `protobuf:"bytes,15,opt,name=uid,proto3" json:"uid,omitempty"`
+	Url          string      `protobuf:"bytes,16,opt,name=url,proto3" json:"url,omitempty"`
+	Categories   []string    `protobuf:"bytes,17,rep,name=categories,proto3" json:"categories,omitempty"`
+	// timezone is the IANA time zone the event is displayed in, empty if the server's local time zone is used
+	Timezone string `protobuf:"bytes,18,opt,name=timezone,proto3" json:"timezone,omitempty"`
 }
 
 func (x *CalendarEntry) Reset() {
@@ -176,31 +187,957 @@ func (x *CalendarEntry) GetCalendarName() string {
 	return ""
 }
 
+func (x *CalendarEntry) GetIcon() string {
+	if x != nil {
+		return x.Icon
+	}
+	return ""
+}
+
+func (x *CalendarEntry) GetPrivate() bool {
+	if x != nil {
+		return x.Private
+	}
+	return false
+}
+
+func (x *CalendarEntry) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *CalendarEntry) GetOrganizer() *Person {
+	if x != nil {
+		return x.Organizer
+	}
+	return nil
+}
+
+func (x *CalendarEntry) GetAttendees() []*Attendee {
+	if x != nil {
+		return x.Attendees
+	}
+	return nil
+}
+
+func (x *CalendarEntry) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CalendarEntry) GetUid() string {
+	if x != nil {
+		return x.Uid
+	}
+	return ""
+}
+
+func (x *CalendarEntry) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *CalendarEntry) GetCategories() []string {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+func (x *CalendarEntry) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+type Person struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name  string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Email string `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+}
+
+func (x *Person) Reset() {
+	*x = Person{}
+	mi := &file_calendar_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Person) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Person) ProtoMessage() {}
+
+func (x *Person) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Person.ProtoReflect.Descriptor instead.
+func (*Person) Descriptor() ([]byte, []int) {
+	return file_calendar_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Person) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Person) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+type Attendee struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name  string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Email string `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	// status is the attendee's participation status, e.g. ACCEPTED, DECLINED or TENTATIVE
+	Status string `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+}
+
+func (x *Attendee) Reset() {
+	*x = Attendee{}
+	mi := &file_calendar_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Attendee) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Attendee) ProtoMessage() {}
+
+func (x *Attendee) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Attendee.ProtoReflect.Descriptor instead.
+func (*Attendee) Descriptor() ([]byte, []int) {
+	return file_calendar_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Attendee) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Attendee) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *Attendee) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
 type CalendarResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	LastUpdated  int64            `protobuf:"varint,1,opt,name=last_updated,json=lastUpdated,proto3" json:"last_updated,omitempty"`
-	Entries      []*CalendarEntry `protobuf:"bytes,2,rep,name=entries,proto3" json:"entries,omitempty"`
-	CalendarName string           `protobuf:"bytes,3,opt,name=calendar_name,json=calendarName,proto3" json:"calendar_name,omitempty"`
+	LastUpdated  int64            `protobuf:"varint,1,opt,name=last_updated,json=lastUpdated,proto3" json:"last_updated,omitempty"`
+	Entries      []*CalendarEntry `protobuf:"bytes,2,rep,name=entries,proto3" json:"entries,omitempty"`
+	CalendarName string           `protobuf:"bytes,3,opt,name=calendar_name,json=calendarName,proto3" json:"calendar_name,omitempty"`
+	From         int64            `protobuf:"varint,4,opt,name=from,proto3" json:"from,omitempty"`
+	To           int64            `protobuf:"varint,5,opt,name=to,proto3" json:"to,omitempty"`
+	Calendars    []*CalendarInfo  `protobuf:"bytes,6,rep,name=calendars,proto3" json:"calendars,omitempty"`
+	// timezone is the IANA time zone of the calendar, or the server's for "all", empty if the server's local time zone is used
+	Timezone string `protobuf:"bytes,7,opt,name=timezone,proto3" json:"timezone,omitempty"`
+}
+
+func (x *CalendarResponse) Reset() {
+	*x = CalendarResponse{}
+	mi := &file_calendar_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CalendarResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CalendarResponse) ProtoMessage() {}
+
+func (x *CalendarResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CalendarResponse.ProtoReflect.Descriptor instead.
+func (*CalendarResponse) Descriptor() ([]byte, []int) {
+	return file_calendar_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CalendarResponse) GetLastUpdated() int64 {
+	if x != nil {
+		return x.LastUpdated
+	}
+	return 0
+}
+
+func (x *CalendarResponse) GetEntries() []*CalendarEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *CalendarResponse) GetCalendarName() string {
+	if x != nil {
+		return x.CalendarName
+	}
+	return ""
+}
+
+func (x *CalendarResponse) GetFrom() int64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *CalendarResponse) GetTo() int64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+func (x *CalendarResponse) GetCalendars() []*CalendarInfo {
+	if x != nil {
+		return x.Calendars
+	}
+	return nil
+}
+
+func (x *CalendarResponse) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+type CalendarInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// stale is set if the last refresh failed and the last successfully loaded events are served instead
+	Stale       bool   `protobuf:"varint,2,opt,name=stale,proto3" json:"stale,omitempty"`
+	LastSuccess int64  `protobuf:"varint,3,opt,name=last_success,json=lastSuccess,proto3" json:"last_success,omitempty"`
+	From        string `protobuf:"bytes,4,opt,name=from,proto3" json:"from,omitempty"`
+	LastRefresh int64  `protobuf:"varint,5,opt,name=last_refresh,json=lastRefresh,proto3" json:"last_refresh,omitempty"`
+	// duration of the last refresh in milliseconds
+	Duration int64 `protobuf:"varint,6,opt,name=duration,proto3" json:"duration,omitempty"`
+	// error of the last refresh, empty if it succeeded
+	LastError  string `protobuf:"bytes,7,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	EventCount int32  `protobuf:"varint,8,opt,name=event_count,json=eventCount,proto3" json:"event_count,omitempty"`
+	// timezone is the IANA time zone the calendar's days start in, empty if the server's local time zone is used
+	Timezone string `protobuf:"bytes,9,opt,name=timezone,proto3" json:"timezone,omitempty"`
+}
+
+func (x *CalendarInfo) Reset() {
+	*x = CalendarInfo{}
+	mi := &file_calendar_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CalendarInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CalendarInfo) ProtoMessage() {}
+
+func (x *CalendarInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CalendarInfo.ProtoReflect.Descriptor instead.
+func (*CalendarInfo) Descriptor() ([]byte, []int) {
+	return file_calendar_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CalendarInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CalendarInfo) GetStale() bool {
+	if x != nil {
+		return x.Stale
+	}
+	return false
+}
+
+func (x *CalendarInfo) GetLastSuccess() int64 {
+	if x != nil {
+		return x.LastSuccess
+	}
+	return 0
+}
+
+func (x *CalendarInfo) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *CalendarInfo) GetLastRefresh() int64 {
+	if x != nil {
+		return x.LastRefresh
+	}
+	return 0
+}
+
+func (x *CalendarInfo) GetDuration() int64 {
+	if x != nil {
+		return x.Duration
+	}
+	return 0
+}
+
+func (x *CalendarInfo) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *CalendarInfo) GetEventCount() int32 {
+	if x != nil {
+		return x.EventCount
+	}
+	return 0
+}
+
+func (x *CalendarInfo) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+type ListCalendarsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListCalendarsRequest) Reset() {
+	*x = ListCalendarsRequest{}
+	mi := &file_calendar_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCalendarsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCalendarsRequest) ProtoMessage() {}
+
+func (x *ListCalendarsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCalendarsRequest.ProtoReflect.Descriptor instead.
+func (*ListCalendarsRequest) Descriptor() ([]byte, []int) {
+	return file_calendar_proto_rawDescGZIP(), []int{5}
+}
+
+type ListCalendarsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Calendars []*CalendarInfo `protobuf:"bytes,1,rep,name=calendars,proto3" json:"calendars,omitempty"`
+}
+
+func (x *ListCalendarsResponse) Reset() {
+	*x = ListCalendarsResponse{}
+	mi := &file_calendar_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCalendarsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCalendarsResponse) ProtoMessage() {}
+
+func (x *ListCalendarsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCalendarsResponse.ProtoReflect.Descriptor instead.
+func (*ListCalendarsResponse) Descriptor() ([]byte, []int) {
+	return file_calendar_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListCalendarsResponse) GetCalendars() []*CalendarInfo {
+	if x != nil {
+		return x.Calendars
+	}
+	return nil
+}
+
+type CalendarRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CalendarName string `protobuf:"bytes,1,opt,name=calendar_name,json=calendarName,proto3" json:"calendar_name,omitempty"`
+	From         int64  `protobuf:"varint,2,opt,name=from,proto3" json:"from,omitempty"`
+	To           int64  `protobuf:"varint,3,opt,name=to,proto3" json:"to,omitempty"`
+}
+
+func (x *CalendarRequest) Reset() {
+	*x = CalendarRequest{}
+	mi := &file_calendar_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CalendarRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CalendarRequest) ProtoMessage() {}
+
+func (x *CalendarRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CalendarRequest.ProtoReflect.Descriptor instead.
+func (*CalendarRequest) Descriptor() ([]byte, []int) {
+	return file_calendar_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CalendarRequest) GetCalendarName() string {
+	if x != nil {
+		return x.CalendarName
+	}
+	return ""
+}
+
+func (x *CalendarRequest) GetFrom() int64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *CalendarRequest) GetTo() int64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+type Availability struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CalendarName string `protobuf:"bytes,1,opt,name=calendar_name,json=calendarName,proto3" json:"calendar_name,omitempty"`
+	// busy is set while an event that isn't free is happening
+	Busy bool `protobuf:"varint,2,opt,name=busy,proto3" json:"busy,omitempty"`
+	// until is when busy changes: the end of the back-to-back or overlapping events while busy, the start of the next
+	// event that isn't free otherwise. 0 if that's beyond the cached window.
+	Until int64 `protobuf:"varint,3,opt,name=until,proto3" json:"until,omitempty"`
+	// timezone is the IANA time zone of the calendar, or the server's for "all", empty if the server's local time zone is used
+	Timezone string `protobuf:"bytes,4,opt,name=timezone,proto3" json:"timezone,omitempty"`
+}
+
+func (x *Availability) Reset() {
+	*x = Availability{}
+	mi := &file_calendar_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Availability) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Availability) ProtoMessage() {}
+
+func (x *Availability) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Availability.ProtoReflect.Descriptor instead.
+func (*Availability) Descriptor() ([]byte, []int) {
+	return file_calendar_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Availability) GetCalendarName() string {
+	if x != nil {
+		return x.CalendarName
+	}
+	return ""
+}
+
+func (x *Availability) GetBusy() bool {
+	if x != nil {
+		return x.Busy
+	}
+	return false
+}
+
+func (x *Availability) GetUntil() int64 {
+	if x != nil {
+		return x.Until
+	}
+	return 0
+}
+
+func (x *Availability) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+type FreeBusyRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// calendar_names are the calendars to merge, all calendars if empty
+	CalendarNames []string `protobuf:"bytes,1,rep,name=calendar_names,json=calendarNames,proto3" json:"calendar_names,omitempty"`
+	From          int64    `protobuf:"varint,2,opt,name=from,proto3" json:"from,omitempty"`
+	To            int64    `protobuf:"varint,3,opt,name=to,proto3" json:"to,omitempty"`
+}
+
+func (x *FreeBusyRequest) Reset() {
+	*x = FreeBusyRequest{}
+	mi := &file_calendar_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FreeBusyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FreeBusyRequest) ProtoMessage() {}
+
+func (x *FreeBusyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FreeBusyRequest.ProtoReflect.Descriptor instead.
+func (*FreeBusyRequest) Descriptor() ([]byte, []int) {
+	return file_calendar_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *FreeBusyRequest) GetCalendarNames() []string {
+	if x != nil {
+		return x.CalendarNames
+	}
+	return nil
+}
+
+func (x *FreeBusyRequest) GetFrom() int64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *FreeBusyRequest) GetTo() int64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+type FreeBusyInterval struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Start int64 `protobuf:"varint,1,opt,name=start,proto3" json:"start,omitempty"`
+	End   int64 `protobuf:"varint,2,opt,name=end,proto3" json:"end,omitempty"`
+	// busy is the strongest state of the events during the interval, intervals are never Free
+	Busy BusyState `protobuf:"varint,3,opt,name=busy,proto3,enum=meetingroom_display_epd.BusyState" json:"busy,omitempty"`
+}
+
+func (x *FreeBusyInterval) Reset() {
+	*x = FreeBusyInterval{}
+	mi := &file_calendar_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FreeBusyInterval) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FreeBusyInterval) ProtoMessage() {}
+
+func (x *FreeBusyInterval) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FreeBusyInterval.ProtoReflect.Descriptor instead.
+func (*FreeBusyInterval) Descriptor() ([]byte, []int) {
+	return file_calendar_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *FreeBusyInterval) GetStart() int64 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *FreeBusyInterval) GetEnd() int64 {
+	if x != nil {
+		return x.End
+	}
+	return 0
+}
+
+func (x *FreeBusyInterval) GetBusy() BusyState {
+	if x != nil {
+		return x.Busy
+	}
+	return BusyState_Free
+}
+
+type FreeBusyResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CalendarNames []string `protobuf:"bytes,1,rep,name=calendar_names,json=calendarNames,proto3" json:"calendar_names,omitempty"`
+	From          int64    `protobuf:"varint,2,opt,name=from,proto3" json:"from,omitempty"`
+	To            int64    `protobuf:"varint,3,opt,name=to,proto3" json:"to,omitempty"`
+	// intervals are the merged, non-overlapping intervals the calendars aren't free in, sorted by start
+	Intervals   []*FreeBusyInterval `protobuf:"bytes,4,rep,name=intervals,proto3" json:"intervals,omitempty"`
+	LastUpdated int64               `protobuf:"varint,5,opt,name=last_updated,json=lastUpdated,proto3" json:"last_updated,omitempty"`
+}
+
+func (x *FreeBusyResponse) Reset() {
+	*x = FreeBusyResponse{}
+	mi := &file_calendar_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FreeBusyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FreeBusyResponse) ProtoMessage() {}
+
+func (x *FreeBusyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FreeBusyResponse.ProtoReflect.Descriptor instead.
+func (*FreeBusyResponse) Descriptor() ([]byte, []int) {
+	return file_calendar_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *FreeBusyResponse) GetCalendarNames() []string {
+	if x != nil {
+		return x.CalendarNames
+	}
+	return nil
+}
+
+func (x *FreeBusyResponse) GetFrom() int64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *FreeBusyResponse) GetTo() int64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+func (x *FreeBusyResponse) GetIntervals() []*FreeBusyInterval {
+	if x != nil {
+		return x.Intervals
+	}
+	return nil
+}
+
+func (x *FreeBusyResponse) GetLastUpdated() int64 {
+	if x != nil {
+		return x.LastUpdated
+	}
+	return 0
+}
+
+type FindSlotsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// calendar_names must all be free during a slot, all calendars if empty
+	CalendarNames []string `protobuf:"bytes,1,rep,name=calendar_names,json=calendarNames,proto3" json:"calendar_names,omitempty"`
+	// duration of the slots in seconds
+	Duration int64 `protobuf:"varint,2,opt,name=duration,proto3" json:"duration,omitempty"`
+	// the search window [from, to), defaults to [now, end of the cached window)
+	From int64 `protobuf:"varint,3,opt,name=from,proto3" json:"from,omitempty"`
+	To   int64 `protobuf:"varint,4,opt,name=to,proto3" json:"to,omitempty"`
+	// slots lie within the working hours (15:04) of a day, which default to the whole day
+	WorkingHoursStart string `protobuf:"bytes,5,opt,name=working_hours_start,json=workingHoursStart,proto3" json:"working_hours_start,omitempty"`
+	WorkingHoursEnd   string `protobuf:"bytes,6,opt,name=working_hours_end,json=workingHoursEnd,proto3" json:"working_hours_end,omitempty"`
+	// working_days (e.g. Monday or Mon) slots lie on, every day if empty
+	WorkingDays []string `protobuf:"bytes,7,rep,name=working_days,json=workingDays,proto3" json:"working_days,omitempty"`
+	// timezone (IANA) of the working hours, defaults to the server's
+	Timezone string `protobuf:"bytes,8,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	// limit the number of slots, defaults to 10
+	Limit int32 `protobuf:"varint,9,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *FindSlotsRequest) Reset() {
+	*x = FindSlotsRequest{}
+	mi := &file_calendar_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindSlotsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindSlotsRequest) ProtoMessage() {}
+
+func (x *FindSlotsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindSlotsRequest.ProtoReflect.Descriptor instead.
+func (*FindSlotsRequest) Descriptor() ([]byte, []int) {
+	return file_calendar_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *FindSlotsRequest) GetCalendarNames() []string {
+	if x != nil {
+		return x.CalendarNames
+	}
+	return nil
+}
+
+func (x *FindSlotsRequest) GetDuration() int64 {
+	if x != nil {
+		return x.Duration
+	}
+	return 0
+}
+
+func (x *FindSlotsRequest) GetFrom() int64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *FindSlotsRequest) GetTo() int64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+func (x *FindSlotsRequest) GetWorkingHoursStart() string {
+	if x != nil {
+		return x.WorkingHoursStart
+	}
+	return ""
+}
+
+func (x *FindSlotsRequest) GetWorkingHoursEnd() string {
+	if x != nil {
+		return x.WorkingHoursEnd
+	}
+	return ""
+}
+
+func (x *FindSlotsRequest) GetWorkingDays() []string {
+	if x != nil {
+		return x.WorkingDays
+	}
+	return nil
+}
+
+func (x *FindSlotsRequest) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *FindSlotsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type Slot struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Start int64 `protobuf:"varint,1,opt,name=start,proto3" json:"start,omitempty"`
+	End   int64 `protobuf:"varint,2,opt,name=end,proto3" json:"end,omitempty"`
+	// free_until is the end of the free time the slot starts, slots can be extended up to it
+	FreeUntil int64 `protobuf:"varint,3,opt,name=free_until,json=freeUntil,proto3" json:"free_until,omitempty"`
 }
 
-func (x *CalendarResponse) Reset() {
-	*x = CalendarResponse{}
-	mi := &file_calendar_proto_msgTypes[1]
+func (x *Slot) Reset() {
+	*x = Slot{}
+	mi := &file_calendar_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CalendarResponse) String() string {
+func (x *Slot) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CalendarResponse) ProtoMessage() {}
+func (*Slot) ProtoMessage() {}
 
-func (x *CalendarResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_calendar_proto_msgTypes[1]
+func (x *Slot) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -211,55 +1148,61 @@ func (x *CalendarResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CalendarResponse.ProtoReflect.Descriptor instead.
-func (*CalendarResponse) Descriptor() ([]byte, []int) {
-	return file_calendar_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use Slot.ProtoReflect.Descriptor instead.
+func (*Slot) Descriptor() ([]byte, []int) {
+	return file_calendar_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *CalendarResponse) GetLastUpdated() int64 {
+func (x *Slot) GetStart() int64 {
 	if x != nil {
-		return x.LastUpdated
+		return x.Start
 	}
 	return 0
 }
 
-func (x *CalendarResponse) GetEntries() []*CalendarEntry {
+func (x *Slot) GetEnd() int64 {
 	if x != nil {
-		return x.Entries
+		return x.End
 	}
-	return nil
+	return 0
 }
 
-func (x *CalendarResponse) GetCalendarName() string {
+func (x *Slot) GetFreeUntil() int64 {
 	if x != nil {
-		return x.CalendarName
+		return x.FreeUntil
 	}
-	return ""
+	return 0
 }
 
-type CalendarRequest struct {
+type FindSlotsResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	CalendarName string `protobuf:"bytes,1,opt,name=calendar_name,json=calendarName,proto3" json:"calendar_name,omitempty"`
+	CalendarNames []string `protobuf:"bytes,1,rep,name=calendar_names,json=calendarNames,proto3" json:"calendar_names,omitempty"`
+	Duration      int64    `protobuf:"varint,2,opt,name=duration,proto3" json:"duration,omitempty"`
+	From          int64    `protobuf:"varint,3,opt,name=from,proto3" json:"from,omitempty"`
+	To            int64    `protobuf:"varint,4,opt,name=to,proto3" json:"to,omitempty"`
+	// slots are the candidate slots, earliest first. Every period of free time yields one slot at its start.
+	Slots    []*Slot `protobuf:"bytes,5,rep,name=slots,proto3" json:"slots,omitempty"`
+	Timezone string  `protobuf:"bytes,6,opt,name=timezone,proto3" json:"timezone,omitempty"`
 }
 
-func (x *CalendarRequest) Reset() {
-	*x = CalendarRequest{}
-	mi := &file_calendar_proto_msgTypes[2]
+func (x *FindSlotsResponse) Reset() {
+	*x = FindSlotsResponse{}
+	mi := &file_calendar_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CalendarRequest) String() string {
+func (x *FindSlotsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CalendarRequest) ProtoMessage() {}
+func (*FindSlotsResponse) ProtoMessage() {}
 
-func (x *CalendarRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_calendar_proto_msgTypes[2]
+func (x *FindSlotsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -270,14 +1213,49 @@ func (x *CalendarRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CalendarRequest.ProtoReflect.Descriptor instead.
-func (*CalendarRequest) Descriptor() ([]byte, []int) {
-	return file_calendar_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use FindSlotsResponse.ProtoReflect.Descriptor instead.
+func (*FindSlotsResponse) Descriptor() ([]byte, []int) {
+	return file_calendar_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *CalendarRequest) GetCalendarName() string {
+func (x *FindSlotsResponse) GetCalendarNames() []string {
 	if x != nil {
-		return x.CalendarName
+		return x.CalendarNames
+	}
+	return nil
+}
+
+func (x *FindSlotsResponse) GetDuration() int64 {
+	if x != nil {
+		return x.Duration
+	}
+	return 0
+}
+
+func (x *FindSlotsResponse) GetFrom() int64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *FindSlotsResponse) GetTo() int64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+func (x *FindSlotsResponse) GetSlots() []*Slot {
+	if x != nil {
+		return x.Slots
+	}
+	return nil
+}
+
+func (x *FindSlotsResponse) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
 	}
 	return ""
 }
@@ -292,7 +1270,7 @@ type GetCustomStatusRequest struct {
 
 func (x *GetCustomStatusRequest) Reset() {
 	*x = GetCustomStatusRequest{}
-	mi := &file_calendar_proto_msgTypes[3]
+	mi := &file_calendar_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -304,7 +1282,7 @@ func (x *GetCustomStatusRequest) String() string {
 func (*GetCustomStatusRequest) ProtoMessage() {}
 
 func (x *GetCustomStatusRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_calendar_proto_msgTypes[3]
+	mi := &file_calendar_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -317,7 +1295,7 @@ func (x *GetCustomStatusRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetCustomStatusRequest.ProtoReflect.Descriptor instead.
 func (*GetCustomStatusRequest) Descriptor() ([]byte, []int) {
-	return file_calendar_proto_rawDescGZIP(), []int{3}
+	return file_calendar_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *GetCustomStatusRequest) GetCalendarName() string {
@@ -338,7 +1316,7 @@ type SetCustomStatusRequest struct {
 
 func (x *SetCustomStatusRequest) Reset() {
 	*x = SetCustomStatusRequest{}
-	mi := &file_calendar_proto_msgTypes[4]
+	mi := &file_calendar_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -350,7 +1328,7 @@ func (x *SetCustomStatusRequest) String() string {
 func (*SetCustomStatusRequest) ProtoMessage() {}
 
 func (x *SetCustomStatusRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_calendar_proto_msgTypes[4]
+	mi := &file_calendar_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -363,7 +1341,7 @@ func (x *SetCustomStatusRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SetCustomStatusRequest.ProtoReflect.Descriptor instead.
 func (*SetCustomStatusRequest) Descriptor() ([]byte, []int) {
-	return file_calendar_proto_rawDescGZIP(), []int{4}
+	return file_calendar_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *SetCustomStatusRequest) GetCalendarName() string {
@@ -390,7 +1368,7 @@ type ClearCustomStatusRequest struct {
 
 func (x *ClearCustomStatusRequest) Reset() {
 	*x = ClearCustomStatusRequest{}
-	mi := &file_calendar_proto_msgTypes[5]
+	mi := &file_calendar_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -402,7 +1380,7 @@ func (x *ClearCustomStatusRequest) String() string {
 func (*ClearCustomStatusRequest) ProtoMessage() {}
 
 func (x *ClearCustomStatusRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_calendar_proto_msgTypes[5]
+	mi := &file_calendar_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -415,7 +1393,7 @@ func (x *ClearCustomStatusRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ClearCustomStatusRequest.ProtoReflect.Descriptor instead.
 func (*ClearCustomStatusRequest) Descriptor() ([]byte, []int) {
-	return file_calendar_proto_rawDescGZIP(), []int{5}
+	return file_calendar_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *ClearCustomStatusRequest) GetCalendarName() string {
@@ -431,11 +1409,13 @@ type RefreshCalendarResponse struct {
 	unknownFields protoimpl.UnknownFields
 
 	CalendarName string `protobuf:"bytes,2,opt,name=calendar_name,json=calendarName,proto3" json:"calendar_name,omitempty"`
+	// outcome of the refresh of every refreshed calendar
+	Calendars []*CalendarInfo `protobuf:"bytes,3,rep,name=calendars,proto3" json:"calendars,omitempty"`
 }
 
 func (x *RefreshCalendarResponse) Reset() {
 	*x = RefreshCalendarResponse{}
-	mi := &file_calendar_proto_msgTypes[6]
+	mi := &file_calendar_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -447,7 +1427,7 @@ func (x *RefreshCalendarResponse) String() string {
 func (*RefreshCalendarResponse) ProtoMessage() {}
 
 func (x *RefreshCalendarResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_calendar_proto_msgTypes[6]
+	mi := &file_calendar_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -460,7 +1440,7 @@ func (x *RefreshCalendarResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RefreshCalendarResponse.ProtoReflect.Descriptor instead.
 func (*RefreshCalendarResponse) Descriptor() ([]byte, []int) {
-	return file_calendar_proto_rawDescGZIP(), []int{6}
+	return file_calendar_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *RefreshCalendarResponse) GetCalendarName() string {
@@ -470,6 +1450,210 @@ func (x *RefreshCalendarResponse) GetCalendarName() string {
 	return ""
 }
 
+func (x *RefreshCalendarResponse) GetCalendars() []*CalendarInfo {
+	if x != nil {
+		return x.Calendars
+	}
+	return nil
+}
+
+type WatchRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CalendarName string `protobuf:"bytes,1,opt,name=calendar_name,json=calendarName,proto3" json:"calendar_name,omitempty"`
+	// resume_token of the last update received before reconnecting. The server skips its initial update if nothing changed since.
+	ResumeToken uint64 `protobuf:"varint,2,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
+}
+
+func (x *WatchRequest) Reset() {
+	*x = WatchRequest{}
+	mi := &file_calendar_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchRequest) ProtoMessage() {}
+
+func (x *WatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchRequest.ProtoReflect.Descriptor instead.
+func (*WatchRequest) Descriptor() ([]byte, []int) {
+	return file_calendar_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *WatchRequest) GetCalendarName() string {
+	if x != nil {
+		return x.CalendarName
+	}
+	return ""
+}
+
+func (x *WatchRequest) GetResumeToken() uint64 {
+	if x != nil {
+		return x.ResumeToken
+	}
+	return 0
+}
+
+type WatchCalendarResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// resume_token identifies the update, pass it in the WatchRequest when reconnecting
+	ResumeToken uint64 `protobuf:"varint,1,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
+	// heartbeats only keep the stream alive and carry the resume_token of the last update
+	Heartbeat bool              `protobuf:"varint,2,opt,name=heartbeat,proto3" json:"heartbeat,omitempty"`
+	Calendar  *CalendarResponse `protobuf:"bytes,3,opt,name=calendar,proto3" json:"calendar,omitempty"`
+	Status    *CustomStatus     `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+}
+
+func (x *WatchCalendarResponse) Reset() {
+	*x = WatchCalendarResponse{}
+	mi := &file_calendar_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchCalendarResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchCalendarResponse) ProtoMessage() {}
+
+func (x *WatchCalendarResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchCalendarResponse.ProtoReflect.Descriptor instead.
+func (*WatchCalendarResponse) Descriptor() ([]byte, []int) {
+	return file_calendar_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *WatchCalendarResponse) GetResumeToken() uint64 {
+	if x != nil {
+		return x.ResumeToken
+	}
+	return 0
+}
+
+func (x *WatchCalendarResponse) GetHeartbeat() bool {
+	if x != nil {
+		return x.Heartbeat
+	}
+	return false
+}
+
+func (x *WatchCalendarResponse) GetCalendar() *CalendarResponse {
+	if x != nil {
+		return x.Calendar
+	}
+	return nil
+}
+
+func (x *WatchCalendarResponse) GetStatus() *CustomStatus {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+type WatchCurrentEventResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// resume_token identifies the update, pass it in the WatchRequest when reconnecting
+	ResumeToken uint64 `protobuf:"varint,1,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
+	// heartbeats only keep the stream alive and carry the resume_token of the last update
+	Heartbeat bool `protobuf:"varint,2,opt,name=heartbeat,proto3" json:"heartbeat,omitempty"`
+	// event is unset while there is no current event
+	Event  *CalendarEntry `protobuf:"bytes,3,opt,name=event,proto3" json:"event,omitempty"`
+	Status *CustomStatus  `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+}
+
+func (x *WatchCurrentEventResponse) Reset() {
+	*x = WatchCurrentEventResponse{}
+	mi := &file_calendar_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchCurrentEventResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchCurrentEventResponse) ProtoMessage() {}
+
+func (x *WatchCurrentEventResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchCurrentEventResponse.ProtoReflect.Descriptor instead.
+func (*WatchCurrentEventResponse) Descriptor() ([]byte, []int) {
+	return file_calendar_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *WatchCurrentEventResponse) GetResumeToken() uint64 {
+	if x != nil {
+		return x.ResumeToken
+	}
+	return 0
+}
+
+func (x *WatchCurrentEventResponse) GetHeartbeat() bool {
+	if x != nil {
+		return x.Heartbeat
+	}
+	return false
+}
+
+func (x *WatchCurrentEventResponse) GetEvent() *CalendarEntry {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *WatchCurrentEventResponse) GetStatus() *CustomStatus {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
 type CustomStatus struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -479,11 +1663,14 @@ type CustomStatus struct {
 	IconSize    int32  `protobuf:"varint,2,opt,name=icon_size,json=iconSize,proto3" json:"icon_size,omitempty"`
 	Title       string `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
 	Description string `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	// the status is only shown from valid_from until valid_until (unix timestamps, 0 means unbounded)
+	ValidFrom  int64 `protobuf:"varint,5,opt,name=valid_from,json=validFrom,proto3" json:"valid_from,omitempty"`
+	ValidUntil int64 `protobuf:"varint,6,opt,name=valid_until,json=validUntil,proto3" json:"valid_until,omitempty"`
 }
 
 func (x *CustomStatus) Reset() {
 	*x = CustomStatus{}
-	mi := &file_calendar_proto_msgTypes[7]
+	mi := &file_calendar_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -495,7 +1682,7 @@ func (x *CustomStatus) String() string {
 func (*CustomStatus) ProtoMessage() {}
 
 func (x *CustomStatus) ProtoReflect() protoreflect.Message {
-	mi := &file_calendar_proto_msgTypes[7]
+	mi := &file_calendar_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -508,7 +1695,7 @@ func (x *CustomStatus) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CustomStatus.ProtoReflect.Descriptor instead.
 func (*CustomStatus) Descriptor() ([]byte, []int) {
-	return file_calendar_proto_rawDescGZIP(), []int{7}
+	return file_calendar_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *CustomStatus) GetIcon() string {
@@ -539,12 +1726,26 @@ func (x *CustomStatus) GetDescription() string {
 	return ""
 }
 
+func (x *CustomStatus) GetValidFrom() int64 {
+	if x != nil {
+		return x.ValidFrom
+	}
+	return 0
+}
+
+func (x *CustomStatus) GetValidUntil() int64 {
+	if x != nil {
+		return x.ValidUntil
+	}
+	return 0
+}
+
 var File_calendar_proto protoreflect.FileDescriptor
 
 var file_calendar_proto_rawDesc = []byte{
 	0x0a, 0x0e, 0x63, 0x61, 0x6c, 0x65, 0x6e, 0x64, 0x61, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
 	0x12, 0x17, 0x6d, 0x65, 0x65, 0x74, 0x69, 0x6e, 0x67, 0x72, 0x6f, 0x6f, 0x6d, 0x5f, 0x64, 0x69,
-	0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x65, 0x70, 0x64, 0x22, 0xfb, 0x01, 0x0a, 0x0d, 0x43, 0x61,
+	0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x65, 0x70, 0x64, 0x22, 0xc7, 0x04, 0x0a, 0x0d, 0x43, 0x61,
 	0x6c, 0x65, 0x6e, 0x64, 0x61, 0x72, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x74,
 	0x69, 0x74, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c,
 	0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
@@ -560,98 +1761,320 @@ var file_calendar_proto_rawDesc = []byte{
 	0x61, 0x67, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
 	0x67, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x61, 0x6c, 0x65, 0x6e, 0x64, 0x61, 0x72, 0x5f, 0x6e,
 	0x61, 0x6d, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x61, 0x6c, 0x65, 0x6e,
-	0x64, 0x61, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x9c, 0x01, 0x0a, 0x10, 0x43, 0x61, 0x6c, 0x65,
-	0x6e, 0x64, 0x61, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x21, 0x0a, 0x0c,
-	0x6c, 0x61, 0x73, 0x74, 0x5f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x03, 0x52, 0x0b, 0x6c, 0x61, 0x73, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x12,
-	0x40, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x26, 0x2e, 0x6d, 0x65, 0x65, 0x74, 0x69, 0x6e, 0x67, 0x72, 0x6f, 0x6f, 0x6d, 0x5f, 0x64,
-	0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x65, 0x70, 0x64, 0x2e, 0x43, 0x61, 0x6c, 0x65, 0x6e,
-	0x64, 0x61, 0x72, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65,
-	0x73, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x61, 0x6c, 0x65, 0x6e, 0x64, 0x61, 0x72, 0x5f, 0x6e, 0x61,
-	0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x61, 0x6c, 0x65, 0x6e, 0x64,
-	0x61, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x36, 0x0a, 0x0f, 0x43, 0x61, 0x6c, 0x65, 0x6e, 0x64,
-	0x61, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x61, 0x6c,
-	0x65, 0x6e, 0x64, 0x61, 0x72, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x0c, 0x63, 0x61, 0x6c, 0x65, 0x6e, 0x64, 0x61, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x3d,
-	0x0a, 0x16, 0x47, 0x65, 0x74, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x75,
-	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x61, 0x6c, 0x65,
+	0x64, 0x61, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x69, 0x63, 0x6f, 0x6e, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x69, 0x63, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x70,
+	0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x70, 0x72,
+	0x69, 0x76, 0x61, 0x74, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x3d, 0x0a, 0x09, 0x6f, 0x72, 0x67, 0x61, 0x6e, 0x69, 0x7a, 0x65, 0x72, 0x18, 0x0c,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6d, 0x65, 0x65, 0x74, 0x69, 0x6e, 0x67, 0x72, 0x6f,
+	0x6f, 0x6d, 0x5f, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x65, 0x70, 0x64, 0x2e, 0x50,
+	0x65, 0x72, 0x73, 0x6f, 0x6e, 0x52, 0x09, 0x6f, 0x72, 0x67, 0x61, 0x6e, 0x69, 0x7a, 0x65, 0x72,
+	0x12, 0x3f, 0x0a, 0x09, 0x61, 0x74, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x65, 0x73, 0x18, 0x0d, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6d, 0x65, 0x65, 0x74, 0x69, 0x6e, 0x67, 0x72, 0x6f, 0x6f,
+	0x6d, 0x5f, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x65, 0x70, 0x64, 0x2e, 0x41, 0x74,
+	0x74, 0x65, 0x6e, 0x64, 0x65, 0x65, 0x52, 0x09, 0x61, 0x74, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x65,
+	0x73, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x75, 0x69, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x10, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x61, 0x74, 0x65, 0x67,
+	0x6f, 0x72, 0x69, 0x65, 0x73, 0x18, 0x11, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x61, 0x74,
+	0x65, 0x67, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x7a,
+	0x6f, 0x6e, 0x65, 0x18, 0x12, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x7a,
+	0x6f, 0x6e, 0x65, 0x22, 0x32, 0x0a, 0x06, 0x50, 0x65, 0x72, 0x73, 0x6f, 0x6e, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x22, 0x4c, 0x0a, 0x08, 0x41, 0x74, 0x74, 0x65, 0x6e,
+	0x64, 0x65, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x16, 0x0a,
+	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0xa1, 0x02, 0x0a, 0x10, 0x43, 0x61, 0x6c, 0x65, 0x6e, 0x64,
+	0x61, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x6c, 0x61,
+	0x73, 0x74, 0x5f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0b, 0x6c, 0x61, 0x73, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x12, 0x40, 0x0a,
+	0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x26,
+	0x2e, 0x6d, 0x65, 0x65, 0x74, 0x69, 0x6e, 0x67, 0x72, 0x6f, 0x6f, 0x6d, 0x5f, 0x64, 0x69, 0x73,
+	0x70, 0x6c, 0x61, 0x79, 0x5f, 0x65, 0x70, 0x64, 0x2e, 0x43, 0x61, 0x6c, 0x65, 0x6e, 0x64, 0x61,
+	0x72, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12,
+	0x23, 0x0a, 0x0d, 0x63, 0x61, 0x6c, 0x65, 0x6e, 0x64, 0x61, 0x72, 0x5f, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x61, 0x6c, 0x65, 0x6e, 0x64, 0x61, 0x72,
+	0x4e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x74, 0x6f, 0x12, 0x43, 0x0a, 0x09, 0x63, 0x61, 0x6c, 0x65,
+	0x6e, 0x64, 0x61, 0x72, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x6d, 0x65,
+	0x65, 0x74, 0x69, 0x6e, 0x67, 0x72, 0x6f, 0x6f, 0x6d, 0x5f, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61,
+	0x79, 0x5f, 0x65, 0x70, 0x64, 0x2e, 0x43, 0x61, 0x6c, 0x65, 0x6e, 0x64, 0x61, 0x72, 0x49, 0x6e,
+	0x66, 0x6f, 0x52, 0x09, 0x63, 0x61, 0x6c, 0x65, 0x6e, 0x64, 0x61, 0x72, 0x73, 0x12, 0x1a, 0x0a,
+	0x08, 0x74, 0x69, 0x6d, 0x65, 0x7a, 0x6f, 0x6e, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x74, 0x69, 0x6d, 0x65, 0x7a, 0x6f, 0x6e, 0x65, 0x22, 0x8a, 0x02, 0x0a, 0x0c, 0x43, 0x61,
+	0x6c, 0x65, 0x6e, 0x64, 0x61, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14,
+	0x0a, 0x05, 0x73, 0x74, 0x61, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x73,
+	0x74, 0x61, 0x6c, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x73, 0x75, 0x63,
+	0x63, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x6c, 0x61, 0x73, 0x74,
+	0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x21, 0x0a, 0x0c, 0x6c,
+	0x61, 0x73, 0x74, 0x5f, 0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0b, 0x6c, 0x61, 0x73, 0x74, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x12, 0x1a,
+	0x0a, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x61,
+	0x73, 0x74, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x6c, 0x61, 0x73, 0x74, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x1f, 0x0a, 0x0b, 0x65, 0x76, 0x65,
+	0x6e, 0x74, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a,
+	0x65, 0x76, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x69,
+	0x6d, 0x65, 0x7a, 0x6f, 0x6e, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x69,
+	0x6d, 0x65, 0x7a, 0x6f, 0x6e, 0x65, 0x22, 0x16, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x61,
+	0x6c, 0x65, 0x6e, 0x64, 0x61, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x5c,
+	0x0a, 0x15, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x61, 0x6c, 0x65, 0x6e, 0x64, 0x61, 0x72, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x43, 0x0a, 0x09, 0x63, 0x61, 0x6c, 0x65, 0x6e,
+	0x64, 0x61, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x6d, 0x65, 0x65,
+	0x74, 0x69, 0x6e, 0x67, 0x72, 0x6f, 0x6f, 0x6d, 0x5f, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79,
+	0x5f, 0x65, 0x70, 0x64, 0x2e, 0x43, 0x61, 0x6c, 0x65, 0x6e, 0x64, 0x61, 0x72, 0x49, 0x6e, 0x66,
+	0x6f, 0x52, 0x09, 0x63, 0x61, 0x6c, 0x65, 0x6e, 0x64, 0x61, 0x72, 0x73, 0x22, 0x5a, 0x0a, 0x0f,
+	0x43, 0x61, 0x6c, 0x65, 0x6e, 0x64, 0x61, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x23, 0x0a, 0x0d, 0x63, 0x61, 0x6c, 0x65, 0x6e, 0x64, 0x61, 0x72, 0x5f, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x61, 0x6c, 0x65, 0x6e, 0x64, 0x61, 0x72,
+	0x4e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x74, 0x6f, 0x22, 0x79, 0x0a, 0x0c, 0x41, 0x76, 0x61, 0x69,
+	0x6c, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x61, 0x6c, 0x65,
 	0x6e, 0x64, 0x61, 0x72, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x0c, 0x63, 0x61, 0x6c, 0x65, 0x6e, 0x64, 0x61, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x7c, 0x0a,
-	0x16, 0x53, 0x65, 0x74, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x61, 0x6c, 0x65, 0x6e,
-	0x64, 0x61, 0x72, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
-	0x63, 0x61, 0x6c, 0x65, 0x6e, 0x64, 0x61, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x3d, 0x0a, 0x06,
-	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x6d,
+	0x0c, 0x63, 0x61, 0x6c, 0x65, 0x6e, 0x64, 0x61, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x62, 0x75, 0x73, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x62, 0x75, 0x73,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x75, 0x6e, 0x74, 0x69, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x05, 0x75, 0x6e, 0x74, 0x69, 0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x7a,
+	0x6f, 0x6e, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x7a,
+	0x6f, 0x6e, 0x65, 0x22, 0x5c, 0x0a, 0x0f, 0x46, 0x72, 0x65, 0x65, 0x42, 0x75, 0x73, 0x79, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x61, 0x6c, 0x65, 0x6e, 0x64,
+	0x61, 0x72, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d,
+	0x63, 0x61, 0x6c, 0x65, 0x6e, 0x64, 0x61, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x12, 0x12, 0x0a,
+	0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x66, 0x72, 0x6f,
+	0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x74,
+	0x6f, 0x22, 0x72, 0x0a, 0x10, 0x46, 0x72, 0x65, 0x65, 0x42, 0x75, 0x73, 0x79, 0x49, 0x6e, 0x74,
+	0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x65,
+	0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x65, 0x6e, 0x64, 0x12, 0x36, 0x0a,
+	0x04, 0x62, 0x75, 0x73, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x22, 0x2e, 0x6d, 0x65,
+	0x65, 0x74, 0x69, 0x6e, 0x67, 0x72, 0x6f, 0x6f, 0x6d, 0x5f, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61,
+	0x79, 0x5f, 0x65, 0x70, 0x64, 0x2e, 0x42, 0x75, 0x73, 0x79, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52,
+	0x04, 0x62, 0x75, 0x73, 0x79, 0x22, 0xc9, 0x01, 0x0a, 0x10, 0x46, 0x72, 0x65, 0x65, 0x42, 0x75,
+	0x73, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x61,
+	0x6c, 0x65, 0x6e, 0x64, 0x61, 0x72, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x0d, 0x63, 0x61, 0x6c, 0x65, 0x6e, 0x64, 0x61, 0x72, 0x4e, 0x61, 0x6d, 0x65,
+	0x73, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x02, 0x74, 0x6f, 0x12, 0x47, 0x0a, 0x09, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61,
+	0x6c, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x6d, 0x65, 0x65, 0x74, 0x69,
+	0x6e, 0x67, 0x72, 0x6f, 0x6f, 0x6d, 0x5f, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x65,
+	0x70, 0x64, 0x2e, 0x46, 0x72, 0x65, 0x65, 0x42, 0x75, 0x73, 0x79, 0x49, 0x6e, 0x74, 0x65, 0x72,
+	0x76, 0x61, 0x6c, 0x52, 0x09, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x73, 0x12, 0x21,
+	0x0a, 0x0c, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x6c, 0x61, 0x73, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x64, 0x22, 0xaa, 0x02, 0x0a, 0x10, 0x46, 0x69, 0x6e, 0x64, 0x53, 0x6c, 0x6f, 0x74, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x61, 0x6c, 0x65, 0x6e, 0x64,
+	0x61, 0x72, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d,
+	0x63, 0x61, 0x6c, 0x65, 0x6e, 0x64, 0x61, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x12, 0x1a, 0x0a,
+	0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f,
+	0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a,
+	0x02, 0x74, 0x6f, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x74, 0x6f, 0x12, 0x2e, 0x0a,
+	0x13, 0x77, 0x6f, 0x72, 0x6b, 0x69, 0x6e, 0x67, 0x5f, 0x68, 0x6f, 0x75, 0x72, 0x73, 0x5f, 0x73,
+	0x74, 0x61, 0x72, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x77, 0x6f, 0x72, 0x6b,
+	0x69, 0x6e, 0x67, 0x48, 0x6f, 0x75, 0x72, 0x73, 0x53, 0x74, 0x61, 0x72, 0x74, 0x12, 0x2a, 0x0a,
+	0x11, 0x77, 0x6f, 0x72, 0x6b, 0x69, 0x6e, 0x67, 0x5f, 0x68, 0x6f, 0x75, 0x72, 0x73, 0x5f, 0x65,
+	0x6e, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x77, 0x6f, 0x72, 0x6b, 0x69, 0x6e,
+	0x67, 0x48, 0x6f, 0x75, 0x72, 0x73, 0x45, 0x6e, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x77, 0x6f, 0x72,
+	0x6b, 0x69, 0x6e, 0x67, 0x5f, 0x64, 0x61, 0x79, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x0b, 0x77, 0x6f, 0x72, 0x6b, 0x69, 0x6e, 0x67, 0x44, 0x61, 0x79, 0x73, 0x12, 0x1a, 0x0a, 0x08,
+	0x74, 0x69, 0x6d, 0x65, 0x7a, 0x6f, 0x6e, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x74, 0x69, 0x6d, 0x65, 0x7a, 0x6f, 0x6e, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69,
+	0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x4d,
+	0x0a, 0x04, 0x53, 0x6c, 0x6f, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x10, 0x0a, 0x03,
+	0x65, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x65, 0x6e, 0x64, 0x12, 0x1d,
+	0x0a, 0x0a, 0x66, 0x72, 0x65, 0x65, 0x5f, 0x75, 0x6e, 0x74, 0x69, 0x6c, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x09, 0x66, 0x72, 0x65, 0x65, 0x55, 0x6e, 0x74, 0x69, 0x6c, 0x22, 0xcb, 0x01,
+	0x0a, 0x11, 0x46, 0x69, 0x6e, 0x64, 0x53, 0x6c, 0x6f, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x61, 0x6c, 0x65, 0x6e, 0x64, 0x61, 0x72, 0x5f,
+	0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x63, 0x61, 0x6c,
+	0x65, 0x6e, 0x64, 0x61, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x75,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x64, 0x75,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x74, 0x6f, 0x12, 0x33, 0x0a, 0x05, 0x73, 0x6c,
+	0x6f, 0x74, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x6d, 0x65, 0x65, 0x74,
+	0x69, 0x6e, 0x67, 0x72, 0x6f, 0x6f, 0x6d, 0x5f, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f,
+	0x65, 0x70, 0x64, 0x2e, 0x53, 0x6c, 0x6f, 0x74, 0x52, 0x05, 0x73, 0x6c, 0x6f, 0x74, 0x73, 0x12,
+	0x1a, 0x0a, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x7a, 0x6f, 0x6e, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x7a, 0x6f, 0x6e, 0x65, 0x22, 0x3d, 0x0a, 0x16, 0x47,
+	0x65, 0x74, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x61, 0x6c, 0x65, 0x6e, 0x64, 0x61,
+	0x72, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x61,
+	0x6c, 0x65, 0x6e, 0x64, 0x61, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x7c, 0x0a, 0x16, 0x53, 0x65,
+	0x74, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x61, 0x6c, 0x65, 0x6e, 0x64, 0x61, 0x72,
+	0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x61, 0x6c,
+	0x65, 0x6e, 0x64, 0x61, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x3d, 0x0a, 0x06, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x6d, 0x65, 0x65, 0x74,
+	0x69, 0x6e, 0x67, 0x72, 0x6f, 0x6f, 0x6d, 0x5f, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f,
+	0x65, 0x70, 0x64, 0x2e, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x3f, 0x0a, 0x18, 0x43, 0x6c, 0x65, 0x61,
+	0x72, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x61, 0x6c, 0x65, 0x6e, 0x64, 0x61, 0x72,
+	0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x61, 0x6c,
+	0x65, 0x6e, 0x64, 0x61, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x83, 0x01, 0x0a, 0x17, 0x52, 0x65,
+	0x66, 0x72, 0x65, 0x73, 0x68, 0x43, 0x61, 0x6c, 0x65, 0x6e, 0x64, 0x61, 0x72, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x61, 0x6c, 0x65, 0x6e, 0x64, 0x61,
+	0x72, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x61,
+	0x6c, 0x65, 0x6e, 0x64, 0x61, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x43, 0x0a, 0x09, 0x63, 0x61,
+	0x6c, 0x65, 0x6e, 0x64, 0x61, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x25, 0x2e,
+	0x6d, 0x65, 0x65, 0x74, 0x69, 0x6e, 0x67, 0x72, 0x6f, 0x6f, 0x6d, 0x5f, 0x64, 0x69, 0x73, 0x70,
+	0x6c, 0x61, 0x79, 0x5f, 0x65, 0x70, 0x64, 0x2e, 0x43, 0x61, 0x6c, 0x65, 0x6e, 0x64, 0x61, 0x72,
+	0x49, 0x6e, 0x66, 0x6f, 0x52, 0x09, 0x63, 0x61, 0x6c, 0x65, 0x6e, 0x64, 0x61, 0x72, 0x73, 0x22,
+	0x56, 0x0a, 0x0c, 0x57, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x23, 0x0a, 0x0d, 0x63, 0x61, 0x6c, 0x65, 0x6e, 0x64, 0x61, 0x72, 0x5f, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x61, 0x6c, 0x65, 0x6e, 0x64, 0x61, 0x72,
+	0x4e, 0x61, 0x6d, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x5f, 0x74,
+	0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x72, 0x65, 0x73, 0x75,
+	0x6d, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0xde, 0x01, 0x0a, 0x15, 0x57, 0x61, 0x74, 0x63,
+	0x68, 0x43, 0x61, 0x6c, 0x65, 0x6e, 0x64, 0x61, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65,
+	0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x54,
+	0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x68, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x68, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65,
+	0x61, 0x74, 0x12, 0x45, 0x0a, 0x08, 0x63, 0x61, 0x6c, 0x65, 0x6e, 0x64, 0x61, 0x72, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x6d, 0x65, 0x65, 0x74, 0x69, 0x6e, 0x67, 0x72, 0x6f,
+	0x6f, 0x6d, 0x5f, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x65, 0x70, 0x64, 0x2e, 0x43,
+	0x61, 0x6c, 0x65, 0x6e, 0x64, 0x61, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52,
+	0x08, 0x63, 0x61, 0x6c, 0x65, 0x6e, 0x64, 0x61, 0x72, 0x12, 0x3d, 0x0a, 0x06, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x6d, 0x65, 0x65, 0x74,
+	0x69, 0x6e, 0x67, 0x72, 0x6f, 0x6f, 0x6d, 0x5f, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f,
+	0x65, 0x70, 0x64, 0x2e, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0xd9, 0x01, 0x0a, 0x19, 0x57, 0x61, 0x74,
+	0x63, 0x68, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65,
+	0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x72, 0x65,
+	0x73, 0x75, 0x6d, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x68, 0x65, 0x61,
+	0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x68, 0x65,
+	0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x12, 0x3c, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x6d, 0x65, 0x65, 0x74, 0x69, 0x6e, 0x67,
+	0x72, 0x6f, 0x6f, 0x6d, 0x5f, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x65, 0x70, 0x64,
+	0x2e, 0x43, 0x61, 0x6c, 0x65, 0x6e, 0x64, 0x61, 0x72, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05,
+	0x65, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x3d, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x6d, 0x65, 0x65, 0x74, 0x69, 0x6e, 0x67, 0x72,
+	0x6f, 0x6f, 0x6d, 0x5f, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x65, 0x70, 0x64, 0x2e,
+	0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x22, 0xb7, 0x01, 0x0a, 0x0c, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x69, 0x63, 0x6f, 0x6e, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x69, 0x63, 0x6f, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x69, 0x63, 0x6f,
+	0x6e, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x69, 0x63,
+	0x6f, 0x6e, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x20, 0x0a, 0x0b,
+	0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1d,
+	0x0a, 0x0a, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x5f, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x09, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x46, 0x72, 0x6f, 0x6d, 0x12, 0x1f, 0x0a,
+	0x0b, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x5f, 0x75, 0x6e, 0x74, 0x69, 0x6c, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0a, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x55, 0x6e, 0x74, 0x69, 0x6c, 0x2a, 0x55,
+	0x0a, 0x09, 0x42, 0x75, 0x73, 0x79, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x08, 0x0a, 0x04, 0x46,
+	0x72, 0x65, 0x65, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x54, 0x65, 0x6e, 0x74, 0x61, 0x74, 0x69,
+	0x76, 0x65, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x42, 0x75, 0x73, 0x79, 0x10, 0x02, 0x12, 0x0f,
+	0x0a, 0x0b, 0x4f, 0x75, 0x74, 0x4f, 0x66, 0x4f, 0x66, 0x66, 0x69, 0x63, 0x65, 0x10, 0x03, 0x12,
+	0x14, 0x0a, 0x10, 0x57, 0x6f, 0x72, 0x6b, 0x69, 0x6e, 0x67, 0x45, 0x6c, 0x73, 0x65, 0x77, 0x68,
+	0x65, 0x72, 0x65, 0x10, 0x04, 0x32, 0x82, 0x0b, 0x0a, 0x0f, 0x43, 0x61, 0x6c, 0x65, 0x6e, 0x64,
+	0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x64, 0x0a, 0x0b, 0x47, 0x65, 0x74,
+	0x43, 0x61, 0x6c, 0x65, 0x6e, 0x64, 0x61, 0x72, 0x12, 0x28, 0x2e, 0x6d, 0x65, 0x65, 0x74, 0x69,
+	0x6e, 0x67, 0x72, 0x6f, 0x6f, 0x6d, 0x5f, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x65,
+	0x70, 0x64, 0x2e, 0x43, 0x61, 0x6c, 0x65, 0x6e, 0x64, 0x61, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x29, 0x2e, 0x6d, 0x65, 0x65, 0x74, 0x69, 0x6e, 0x67, 0x72, 0x6f, 0x6f, 0x6d,
+	0x5f, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x65, 0x70, 0x64, 0x2e, 0x43, 0x61, 0x6c,
+	0x65, 0x6e, 0x64, 0x61, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
+	0x65, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x45, 0x76, 0x65,
+	0x6e, 0x74, 0x12, 0x28, 0x2e, 0x6d, 0x65, 0x65, 0x74, 0x69, 0x6e, 0x67, 0x72, 0x6f, 0x6f, 0x6d,
+	0x5f, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x65, 0x70, 0x64, 0x2e, 0x43, 0x61, 0x6c,
+	0x65, 0x6e, 0x64, 0x61, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x6d,
 	0x65, 0x65, 0x74, 0x69, 0x6e, 0x67, 0x72, 0x6f, 0x6f, 0x6d, 0x5f, 0x64, 0x69, 0x73, 0x70, 0x6c,
-	0x61, 0x79, 0x5f, 0x65, 0x70, 0x64, 0x2e, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x53, 0x74, 0x61,
-	0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x3f, 0x0a, 0x18, 0x43,
-	0x6c, 0x65, 0x61, 0x72, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x61, 0x6c, 0x65, 0x6e,
-	0x64, 0x61, 0x72, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
-	0x63, 0x61, 0x6c, 0x65, 0x6e, 0x64, 0x61, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x3e, 0x0a, 0x17,
-	0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x43, 0x61, 0x6c, 0x65, 0x6e, 0x64, 0x61, 0x72, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x61, 0x6c, 0x65, 0x6e,
-	0x64, 0x61, 0x72, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
-	0x63, 0x61, 0x6c, 0x65, 0x6e, 0x64, 0x61, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x77, 0x0a, 0x0c,
-	0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x12, 0x0a, 0x04,
-	0x69, 0x63, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x69, 0x63, 0x6f, 0x6e,
-	0x12, 0x1b, 0x0a, 0x09, 0x69, 0x63, 0x6f, 0x6e, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x05, 0x52, 0x08, 0x69, 0x63, 0x6f, 0x6e, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x14, 0x0a,
-	0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69,
-	0x74, 0x6c, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69,
-	0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69,
-	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x2a, 0x55, 0x0a, 0x09, 0x42, 0x75, 0x73, 0x79, 0x53, 0x74, 0x61,
-	0x74, 0x65, 0x12, 0x08, 0x0a, 0x04, 0x46, 0x72, 0x65, 0x65, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09,
-	0x54, 0x65, 0x6e, 0x74, 0x61, 0x74, 0x69, 0x76, 0x65, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x42,
-	0x75, 0x73, 0x79, 0x10, 0x02, 0x12, 0x0f, 0x0a, 0x0b, 0x4f, 0x75, 0x74, 0x4f, 0x66, 0x4f, 0x66,
-	0x66, 0x69, 0x63, 0x65, 0x10, 0x03, 0x12, 0x14, 0x0a, 0x10, 0x57, 0x6f, 0x72, 0x6b, 0x69, 0x6e,
-	0x67, 0x45, 0x6c, 0x73, 0x65, 0x77, 0x68, 0x65, 0x72, 0x65, 0x10, 0x04, 0x32, 0x9a, 0x05, 0x0a,
-	0x0f, 0x43, 0x61, 0x6c, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
-	0x12, 0x64, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x43, 0x61, 0x6c, 0x65, 0x6e, 0x64, 0x61, 0x72, 0x12,
+	0x61, 0x79, 0x5f, 0x65, 0x70, 0x64, 0x2e, 0x43, 0x61, 0x6c, 0x65, 0x6e, 0x64, 0x61, 0x72, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x22, 0x00, 0x12, 0x62, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x4e, 0x65, 0x78,
+	0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x28, 0x2e, 0x6d, 0x65, 0x65, 0x74, 0x69, 0x6e, 0x67,
+	0x72, 0x6f, 0x6f, 0x6d, 0x5f, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x65, 0x70, 0x64,
+	0x2e, 0x43, 0x61, 0x6c, 0x65, 0x6e, 0x64, 0x61, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x26, 0x2e, 0x6d, 0x65, 0x65, 0x74, 0x69, 0x6e, 0x67, 0x72, 0x6f, 0x6f, 0x6d, 0x5f, 0x64,
+	0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x65, 0x70, 0x64, 0x2e, 0x43, 0x61, 0x6c, 0x65, 0x6e,
+	0x64, 0x61, 0x72, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x22, 0x00, 0x12, 0x64, 0x0a, 0x0f, 0x47, 0x65,
+	0x74, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x28, 0x2e,
+	0x6d, 0x65, 0x65, 0x74, 0x69, 0x6e, 0x67, 0x72, 0x6f, 0x6f, 0x6d, 0x5f, 0x64, 0x69, 0x73, 0x70,
+	0x6c, 0x61, 0x79, 0x5f, 0x65, 0x70, 0x64, 0x2e, 0x43, 0x61, 0x6c, 0x65, 0x6e, 0x64, 0x61, 0x72,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x6d, 0x65, 0x65, 0x74, 0x69, 0x6e,
+	0x67, 0x72, 0x6f, 0x6f, 0x6d, 0x5f, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x65, 0x70,
+	0x64, 0x2e, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x22, 0x00,
+	0x12, 0x64, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x46, 0x72, 0x65, 0x65, 0x42, 0x75, 0x73, 0x79, 0x12,
 	0x28, 0x2e, 0x6d, 0x65, 0x65, 0x74, 0x69, 0x6e, 0x67, 0x72, 0x6f, 0x6f, 0x6d, 0x5f, 0x64, 0x69,
-	0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x65, 0x70, 0x64, 0x2e, 0x43, 0x61, 0x6c, 0x65, 0x6e, 0x64,
-	0x61, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x29, 0x2e, 0x6d, 0x65, 0x65, 0x74,
+	0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x65, 0x70, 0x64, 0x2e, 0x46, 0x72, 0x65, 0x65, 0x42, 0x75,
+	0x73, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x29, 0x2e, 0x6d, 0x65, 0x65, 0x74,
 	0x69, 0x6e, 0x67, 0x72, 0x6f, 0x6f, 0x6d, 0x5f, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f,
-	0x65, 0x70, 0x64, 0x2e, 0x43, 0x61, 0x6c, 0x65, 0x6e, 0x64, 0x61, 0x72, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x65, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x43, 0x75, 0x72,
-	0x72, 0x65, 0x6e, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x28, 0x2e, 0x6d, 0x65, 0x65, 0x74,
+	0x65, 0x70, 0x64, 0x2e, 0x46, 0x72, 0x65, 0x65, 0x42, 0x75, 0x73, 0x79, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x64, 0x0a, 0x09, 0x46, 0x69, 0x6e, 0x64, 0x53, 0x6c,
+	0x6f, 0x74, 0x73, 0x12, 0x29, 0x2e, 0x6d, 0x65, 0x65, 0x74, 0x69, 0x6e, 0x67, 0x72, 0x6f, 0x6f,
+	0x6d, 0x5f, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x65, 0x70, 0x64, 0x2e, 0x46, 0x69,
+	0x6e, 0x64, 0x53, 0x6c, 0x6f, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2a,
+	0x2e, 0x6d, 0x65, 0x65, 0x74, 0x69, 0x6e, 0x67, 0x72, 0x6f, 0x6f, 0x6d, 0x5f, 0x64, 0x69, 0x73,
+	0x70, 0x6c, 0x61, 0x79, 0x5f, 0x65, 0x70, 0x64, 0x2e, 0x46, 0x69, 0x6e, 0x64, 0x53, 0x6c, 0x6f,
+	0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x6f, 0x0a, 0x0f,
+	0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x43, 0x61, 0x6c, 0x65, 0x6e, 0x64, 0x61, 0x72, 0x12,
+	0x28, 0x2e, 0x6d, 0x65, 0x65, 0x74, 0x69, 0x6e, 0x67, 0x72, 0x6f, 0x6f, 0x6d, 0x5f, 0x64, 0x69,
+	0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x65, 0x70, 0x64, 0x2e, 0x43, 0x61, 0x6c, 0x65, 0x6e, 0x64,
+	0x61, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x30, 0x2e, 0x6d, 0x65, 0x65, 0x74,
 	0x69, 0x6e, 0x67, 0x72, 0x6f, 0x6f, 0x6d, 0x5f, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f,
-	0x65, 0x70, 0x64, 0x2e, 0x43, 0x61, 0x6c, 0x65, 0x6e, 0x64, 0x61, 0x72, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x6d, 0x65, 0x65, 0x74, 0x69, 0x6e, 0x67, 0x72, 0x6f, 0x6f,
-	0x6d, 0x5f, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x65, 0x70, 0x64, 0x2e, 0x43, 0x61,
-	0x6c, 0x65, 0x6e, 0x64, 0x61, 0x72, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x22, 0x00, 0x12, 0x6f, 0x0a,
-	0x0f, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x43, 0x61, 0x6c, 0x65, 0x6e, 0x64, 0x61, 0x72,
-	0x12, 0x28, 0x2e, 0x6d, 0x65, 0x65, 0x74, 0x69, 0x6e, 0x67, 0x72, 0x6f, 0x6f, 0x6d, 0x5f, 0x64,
-	0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x65, 0x70, 0x64, 0x2e, 0x43, 0x61, 0x6c, 0x65, 0x6e,
-	0x64, 0x61, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x30, 0x2e, 0x6d, 0x65, 0x65,
-	0x74, 0x69, 0x6e, 0x67, 0x72, 0x6f, 0x6f, 0x6d, 0x5f, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79,
-	0x5f, 0x65, 0x70, 0x64, 0x2e, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x43, 0x61, 0x6c, 0x65,
-	0x6e, 0x64, 0x61, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x6b,
-	0x0a, 0x0f, 0x47, 0x65, 0x74, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x75,
-	0x73, 0x12, 0x2f, 0x2e, 0x6d, 0x65, 0x65, 0x74, 0x69, 0x6e, 0x67, 0x72, 0x6f, 0x6f, 0x6d, 0x5f,
-	0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x65, 0x70, 0x64, 0x2e, 0x47, 0x65, 0x74, 0x43,
-	0x75, 0x73, 0x74, 0x6f, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x25, 0x2e, 0x6d, 0x65, 0x65, 0x74, 0x69, 0x6e, 0x67, 0x72, 0x6f, 0x6f, 0x6d,
-	0x5f, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x65, 0x70, 0x64, 0x2e, 0x43, 0x75, 0x73,
-	0x74, 0x6f, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x00, 0x12, 0x6b, 0x0a, 0x0f, 0x53,
-	0x65, 0x74, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x2f,
+	0x65, 0x70, 0x64, 0x2e, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x43, 0x61, 0x6c, 0x65, 0x6e,
+	0x64, 0x61, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x70, 0x0a,
+	0x0d, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x61, 0x6c, 0x65, 0x6e, 0x64, 0x61, 0x72, 0x73, 0x12, 0x2d,
 	0x2e, 0x6d, 0x65, 0x65, 0x74, 0x69, 0x6e, 0x67, 0x72, 0x6f, 0x6f, 0x6d, 0x5f, 0x64, 0x69, 0x73,
-	0x70, 0x6c, 0x61, 0x79, 0x5f, 0x65, 0x70, 0x64, 0x2e, 0x53, 0x65, 0x74, 0x43, 0x75, 0x73, 0x74,
-	0x6f, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x25, 0x2e, 0x6d, 0x65, 0x65, 0x74, 0x69, 0x6e, 0x67, 0x72, 0x6f, 0x6f, 0x6d, 0x5f, 0x64, 0x69,
-	0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x65, 0x70, 0x64, 0x2e, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d,
-	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x00, 0x12, 0x6f, 0x0a, 0x11, 0x43, 0x6c, 0x65, 0x61,
-	0x72, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x31, 0x2e,
+	0x70, 0x6c, 0x61, 0x79, 0x5f, 0x65, 0x70, 0x64, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x61, 0x6c,
+	0x65, 0x6e, 0x64, 0x61, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2e, 0x2e,
 	0x6d, 0x65, 0x65, 0x74, 0x69, 0x6e, 0x67, 0x72, 0x6f, 0x6f, 0x6d, 0x5f, 0x64, 0x69, 0x73, 0x70,
-	0x6c, 0x61, 0x79, 0x5f, 0x65, 0x70, 0x64, 0x2e, 0x43, 0x6c, 0x65, 0x61, 0x72, 0x43, 0x75, 0x73,
+	0x6c, 0x61, 0x79, 0x5f, 0x65, 0x70, 0x64, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x61, 0x6c, 0x65,
+	0x6e, 0x64, 0x61, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
+	0x6a, 0x0a, 0x0d, 0x57, 0x61, 0x74, 0x63, 0x68, 0x43, 0x61, 0x6c, 0x65, 0x6e, 0x64, 0x61, 0x72,
+	0x12, 0x25, 0x2e, 0x6d, 0x65, 0x65, 0x74, 0x69, 0x6e, 0x67, 0x72, 0x6f, 0x6f, 0x6d, 0x5f, 0x64,
+	0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x65, 0x70, 0x64, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2e, 0x2e, 0x6d, 0x65, 0x65, 0x74, 0x69, 0x6e,
+	0x67, 0x72, 0x6f, 0x6f, 0x6d, 0x5f, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x65, 0x70,
+	0x64, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x43, 0x61, 0x6c, 0x65, 0x6e, 0x64, 0x61, 0x72, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x30, 0x01, 0x12, 0x72, 0x0a, 0x11, 0x57,
+	0x61, 0x74, 0x63, 0x68, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x12, 0x25, 0x2e, 0x6d, 0x65, 0x65, 0x74, 0x69, 0x6e, 0x67, 0x72, 0x6f, 0x6f, 0x6d, 0x5f, 0x64,
+	0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x65, 0x70, 0x64, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x32, 0x2e, 0x6d, 0x65, 0x65, 0x74, 0x69, 0x6e,
+	0x67, 0x72, 0x6f, 0x6f, 0x6d, 0x5f, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x65, 0x70,
+	0x64, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x30, 0x01, 0x12,
+	0x6b, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x2f, 0x2e, 0x6d, 0x65, 0x65, 0x74, 0x69, 0x6e, 0x67, 0x72, 0x6f, 0x6f, 0x6d,
+	0x5f, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x65, 0x70, 0x64, 0x2e, 0x47, 0x65, 0x74,
+	0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x6d, 0x65, 0x65, 0x74, 0x69, 0x6e, 0x67, 0x72, 0x6f, 0x6f,
+	0x6d, 0x5f, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x65, 0x70, 0x64, 0x2e, 0x43, 0x75,
+	0x73, 0x74, 0x6f, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x00, 0x12, 0x6b, 0x0a, 0x0f,
+	0x53, 0x65, 0x74, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
+	0x2f, 0x2e, 0x6d, 0x65, 0x65, 0x74, 0x69, 0x6e, 0x67, 0x72, 0x6f, 0x6f, 0x6d, 0x5f, 0x64, 0x69,
+	0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x65, 0x70, 0x64, 0x2e, 0x53, 0x65, 0x74, 0x43, 0x75, 0x73,
 	0x74, 0x6f, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
 	0x1a, 0x25, 0x2e, 0x6d, 0x65, 0x65, 0x74, 0x69, 0x6e, 0x67, 0x72, 0x6f, 0x6f, 0x6d, 0x5f, 0x64,
 	0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x65, 0x70, 0x64, 0x2e, 0x43, 0x75, 0x73, 0x74, 0x6f,
-	0x6d, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x00, 0x42, 0x21, 0x5a, 0x1f, 0x67, 0x69, 0x74,
-	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x65, 0x64, 0x69, 0x2f, 0x69, 0x63, 0x61,
-	0x6c, 0x74, 0x65, 0x73, 0x74, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x62, 0x06, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x33,
+	0x6d, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x00, 0x12, 0x6f, 0x0a, 0x11, 0x43, 0x6c, 0x65,
+	0x61, 0x72, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x31,
+	0x2e, 0x6d, 0x65, 0x65, 0x74, 0x69, 0x6e, 0x67, 0x72, 0x6f, 0x6f, 0x6d, 0x5f, 0x64, 0x69, 0x73,
+	0x70, 0x6c, 0x61, 0x79, 0x5f, 0x65, 0x70, 0x64, 0x2e, 0x43, 0x6c, 0x65, 0x61, 0x72, 0x43, 0x75,
+	0x73, 0x74, 0x6f, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x25, 0x2e, 0x6d, 0x65, 0x65, 0x74, 0x69, 0x6e, 0x67, 0x72, 0x6f, 0x6f, 0x6d, 0x5f,
+	0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x65, 0x70, 0x64, 0x2e, 0x43, 0x75, 0x73, 0x74,
+	0x6f, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x00, 0x42, 0x21, 0x5a, 0x1f, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x65, 0x64, 0x69, 0x2f, 0x69, 0x63,
+	0x61, 0x6c, 0x74, 0x65, 0x73, 0x74, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -667,39 +2090,80 @@ func file_calendar_proto_rawDescGZIP() []byte {
 }
 
 var file_calendar_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_calendar_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_calendar_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
 var file_calendar_proto_goTypes = []any{
-	(BusyState)(0),                   // 0: meetingroom_display_epd.BusyState
-	(*CalendarEntry)(nil),            // 1: meetingroom_display_epd.CalendarEntry
-	(*CalendarResponse)(nil),         // 2: meetingroom_display_epd.CalendarResponse
-	(*CalendarRequest)(nil),          // 3: meetingroom_display_epd.CalendarRequest
-	(*GetCustomStatusRequest)(nil),   // 4: meetingroom_display_epd.GetCustomStatusRequest
-	(*SetCustomStatusRequest)(nil),   // 5: meetingroom_display_epd.SetCustomStatusRequest
-	(*ClearCustomStatusRequest)(nil), // 6: meetingroom_display_epd.ClearCustomStatusRequest
-	(*RefreshCalendarResponse)(nil),  // 7: meetingroom_display_epd.RefreshCalendarResponse
-	(*CustomStatus)(nil),             // 8: meetingroom_display_epd.CustomStatus
+	(BusyState)(0),                    // 0: meetingroom_display_epd.BusyState
+	(*CalendarEntry)(nil),             // 1: meetingroom_display_epd.CalendarEntry
+	(*Person)(nil),                    // 2: meetingroom_display_epd.Person
+	(*Attendee)(nil),                  // 3: meetingroom_display_epd.Attendee
+	(*CalendarResponse)(nil),          // 4: meetingroom_display_epd.CalendarResponse
+	(*CalendarInfo)(nil),              // 5: meetingroom_display_epd.CalendarInfo
+	(*ListCalendarsRequest)(nil),      // 6: meetingroom_display_epd.ListCalendarsRequest
+	(*ListCalendarsResponse)(nil),     // 7: meetingroom_display_epd.ListCalendarsResponse
+	(*CalendarRequest)(nil),           // 8: meetingroom_display_epd.CalendarRequest
+	(*Availability)(nil),              // 9: meetingroom_display_epd.Availability
+	(*FreeBusyRequest)(nil),           // 10: meetingroom_display_epd.FreeBusyRequest
+	(*FreeBusyInterval)(nil),          // 11: meetingroom_display_epd.FreeBusyInterval
+	(*FreeBusyResponse)(nil),          // 12: meetingroom_display_epd.FreeBusyResponse
+	(*FindSlotsRequest)(nil),          // 13: meetingroom_display_epd.FindSlotsRequest
+	(*Slot)(nil),                      // 14: meetingroom_display_epd.Slot
+	(*FindSlotsResponse)(nil),         // 15: meetingroom_display_epd.FindSlotsResponse
+	(*GetCustomStatusRequest)(nil),    // 16: meetingroom_display_epd.GetCustomStatusRequest
+	(*SetCustomStatusRequest)(nil),    // 17: meetingroom_display_epd.SetCustomStatusRequest
+	(*ClearCustomStatusRequest)(nil),  // 18: meetingroom_display_epd.ClearCustomStatusRequest
+	(*RefreshCalendarResponse)(nil),   // 19: meetingroom_display_epd.RefreshCalendarResponse
+	(*WatchRequest)(nil),              // 20: meetingroom_display_epd.WatchRequest
+	(*WatchCalendarResponse)(nil),     // 21: meetingroom_display_epd.WatchCalendarResponse
+	(*WatchCurrentEventResponse)(nil), // 22: meetingroom_display_epd.WatchCurrentEventResponse
+	(*CustomStatus)(nil),              // 23: meetingroom_display_epd.CustomStatus
 }
 var file_calendar_proto_depIdxs = []int32{
-	0, // 0: meetingroom_display_epd.CalendarEntry.busy:type_name -> meetingroom_display_epd.BusyState
-	1, // 1: meetingroom_display_epd.CalendarResponse.entries:type_name -> meetingroom_display_epd.CalendarEntry
-	8, // 2: meetingroom_display_epd.SetCustomStatusRequest.status:type_name -> meetingroom_display_epd.CustomStatus
-	3, // 3: meetingroom_display_epd.CalenderService.GetCalendar:input_type -> meetingroom_display_epd.CalendarRequest
-	3, // 4: meetingroom_display_epd.CalenderService.GetCurrentEvent:input_type -> meetingroom_display_epd.CalendarRequest
-	3, // 5: meetingroom_display_epd.CalenderService.RefreshCalendar:input_type -> meetingroom_display_epd.CalendarRequest
-	4, // 6: meetingroom_display_epd.CalenderService.GetCustomStatus:input_type -> meetingroom_display_epd.GetCustomStatusRequest
-	5, // 7: meetingroom_display_epd.CalenderService.SetCustomStatus:input_type -> meetingroom_display_epd.SetCustomStatusRequest
-	6, // 8: meetingroom_display_epd.CalenderService.ClearCustomStatus:input_type -> meetingroom_display_epd.ClearCustomStatusRequest
-	2, // 9: meetingroom_display_epd.CalenderService.GetCalendar:output_type -> meetingroom_display_epd.CalendarResponse
-	1, // 10: meetingroom_display_epd.CalenderService.GetCurrentEvent:output_type -> meetingroom_display_epd.CalendarEntry
-	7, // 11: meetingroom_display_epd.CalenderService.RefreshCalendar:output_type -> meetingroom_display_epd.RefreshCalendarResponse
-	8, // 12: meetingroom_display_epd.CalenderService.GetCustomStatus:output_type -> meetingroom_display_epd.CustomStatus
-	8, // 13: meetingroom_display_epd.CalenderService.SetCustomStatus:output_type -> meetingroom_display_epd.CustomStatus
-	8, // 14: meetingroom_display_epd.CalenderService.ClearCustomStatus:output_type -> meetingroom_display_epd.CustomStatus
-	9, // [9:15] is the sub-list for method output_type
-	3, // [3:9] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	0,  // 0: meetingroom_display_epd.CalendarEntry.busy:type_name -> meetingroom_display_epd.BusyState
+	2,  // 1: meetingroom_display_epd.CalendarEntry.organizer:type_name -> meetingroom_display_epd.Person
+	3,  // 2: meetingroom_display_epd.CalendarEntry.attendees:type_name -> meetingroom_display_epd.Attendee
+	1,  // 3: meetingroom_display_epd.CalendarResponse.entries:type_name -> meetingroom_display_epd.CalendarEntry
+	5,  // 4: meetingroom_display_epd.CalendarResponse.calendars:type_name -> meetingroom_display_epd.CalendarInfo
+	5,  // 5: meetingroom_display_epd.ListCalendarsResponse.calendars:type_name -> meetingroom_display_epd.CalendarInfo
+	0,  // 6: meetingroom_display_epd.FreeBusyInterval.busy:type_name -> meetingroom_display_epd.BusyState
+	11, // 7: meetingroom_display_epd.FreeBusyResponse.intervals:type_name -> meetingroom_display_epd.FreeBusyInterval
+	14, // 8: meetingroom_display_epd.FindSlotsResponse.slots:type_name -> meetingroom_display_epd.Slot
+	23, // 9: meetingroom_display_epd.SetCustomStatusRequest.status:type_name -> meetingroom_display_epd.CustomStatus
+	5,  // 10: meetingroom_display_epd.RefreshCalendarResponse.calendars:type_name -> meetingroom_display_epd.CalendarInfo
+	4,  // 11: meetingroom_display_epd.WatchCalendarResponse.calendar:type_name -> meetingroom_display_epd.CalendarResponse
+	23, // 12: meetingroom_display_epd.WatchCalendarResponse.status:type_name -> meetingroom_display_epd.CustomStatus
+	1,  // 13: meetingroom_display_epd.WatchCurrentEventResponse.event:type_name -> meetingroom_display_epd.CalendarEntry
+	23, // 14: meetingroom_display_epd.WatchCurrentEventResponse.status:type_name -> meetingroom_display_epd.CustomStatus
+	8,  // 15: meetingroom_display_epd.CalenderService.GetCalendar:input_type -> meetingroom_display_epd.CalendarRequest
+	8,  // 16: meetingroom_display_epd.CalenderService.GetCurrentEvent:input_type -> meetingroom_display_epd.CalendarRequest
+	8,  // 17: meetingroom_display_epd.CalenderService.GetNextEvent:input_type -> meetingroom_display_epd.CalendarRequest
+	8,  // 18: meetingroom_display_epd.CalenderService.GetAvailability:input_type -> meetingroom_display_epd.CalendarRequest
+	10, // 19: meetingroom_display_epd.CalenderService.GetFreeBusy:input_type -> meetingroom_display_epd.FreeBusyRequest
+	13, // 20: meetingroom_display_epd.CalenderService.FindSlots:input_type -> meetingroom_display_epd.FindSlotsRequest
+	8,  // 21: meetingroom_display_epd.CalenderService.RefreshCalendar:input_type -> meetingroom_display_epd.CalendarRequest
+	6,  // 22: meetingroom_display_epd.CalenderService.ListCalendars:input_type -> meetingroom_display_epd.ListCalendarsRequest
+	20, // 23: meetingroom_display_epd.CalenderService.WatchCalendar:input_type -> meetingroom_display_epd.WatchRequest
+	20, // 24: meetingroom_display_epd.CalenderService.WatchCurrentEvent:input_type -> meetingroom_display_epd.WatchRequest
+	16, // 25: meetingroom_display_epd.CalenderService.GetCustomStatus:input_type -> meetingroom_display_epd.GetCustomStatusRequest
+	17, // 26: meetingroom_display_epd.CalenderService.SetCustomStatus:input_type -> meetingroom_display_epd.SetCustomStatusRequest
+	18, // 27: meetingroom_display_epd.CalenderService.ClearCustomStatus:input_type -> meetingroom_display_epd.ClearCustomStatusRequest
+	4,  // 28: meetingroom_display_epd.CalenderService.GetCalendar:output_type -> meetingroom_display_epd.CalendarResponse
+	1,  // 29: meetingroom_display_epd.CalenderService.GetCurrentEvent:output_type -> meetingroom_display_epd.CalendarEntry
+	1,  // 30: meetingroom_display_epd.CalenderService.GetNextEvent:output_type -> meetingroom_display_epd.CalendarEntry
+	9,  // 31: meetingroom_display_epd.CalenderService.GetAvailability:output_type -> meetingroom_display_epd.Availability
+	12, // 32: meetingroom_display_epd.CalenderService.GetFreeBusy:output_type -> meetingroom_display_epd.FreeBusyResponse
+	15, // 33: meetingroom_display_epd.CalenderService.FindSlots:output_type -> meetingroom_display_epd.FindSlotsResponse
+	19, // 34: meetingroom_display_epd.CalenderService.RefreshCalendar:output_type -> meetingroom_display_epd.RefreshCalendarResponse
+	7,  // 35: meetingroom_display_epd.CalenderService.ListCalendars:output_type -> meetingroom_display_epd.ListCalendarsResponse
+	21, // 36: meetingroom_display_epd.CalenderService.WatchCalendar:output_type -> meetingroom_display_epd.WatchCalendarResponse
+	22, // 37: meetingroom_display_epd.CalenderService.WatchCurrentEvent:output_type -> meetingroom_display_epd.WatchCurrentEventResponse
+	23, // 38: meetingroom_display_epd.CalenderService.GetCustomStatus:output_type -> meetingroom_display_epd.CustomStatus
+	23, // 39: meetingroom_display_epd.CalenderService.SetCustomStatus:output_type -> meetingroom_display_epd.CustomStatus
+	23, // 40: meetingroom_display_epd.CalenderService.ClearCustomStatus:output_type -> meetingroom_display_epd.CustomStatus
+	28, // [28:41] is the sub-list for method output_type
+	15, // [15:28] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
 }
 
 func init() { file_calendar_proto_init() }
@@ -713,7 +2177,7 @@ func file_calendar_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_calendar_proto_rawDesc,
 			NumEnums:      1,
-			NumMessages:   8,
+			NumMessages:   23,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -21,7 +21,14 @@ const _ = grpc.SupportPackageIsVersion9
 const (
 	CalenderService_GetCalendar_FullMethodName       = "/meetingroom_display_epd.CalenderService/GetCalendar"
 	CalenderService_GetCurrentEvent_FullMethodName   = "/meetingroom_display_epd.CalenderService/GetCurrentEvent"
+	CalenderService_GetNextEvent_FullMethodName      = "/meetingroom_display_epd.CalenderService/GetNextEvent"
+	CalenderService_GetAvailability_FullMethodName   = "/meetingroom_display_epd.CalenderService/GetAvailability"
+	CalenderService_GetFreeBusy_FullMethodName       = "/meetingroom_display_epd.CalenderService/GetFreeBusy"
+	CalenderService_FindSlots_FullMethodName         = "/meetingroom_display_epd.CalenderService/FindSlots"
 	CalenderService_RefreshCalendar_FullMethodName   = "/meetingroom_display_epd.CalenderService/RefreshCalendar"
+	CalenderService_ListCalendars_FullMethodName     = "/meetingroom_display_epd.CalenderService/ListCalendars"
+	CalenderService_WatchCalendar_FullMethodName     = "/meetingroom_display_epd.CalenderService/WatchCalendar"
+	CalenderService_WatchCurrentEvent_FullMethodName = "/meetingroom_display_epd.CalenderService/WatchCurrentEvent"
 	CalenderService_GetCustomStatus_FullMethodName   = "/meetingroom_display_epd.CalenderService/GetCustomStatus"
 	CalenderService_SetCustomStatus_FullMethodName   = "/meetingroom_display_epd.CalenderService/SetCustomStatus"
 	CalenderService_ClearCustomStatus_FullMethodName = "/meetingroom_display_epd.CalenderService/ClearCustomStatus"
@@ -33,7 +40,14 @@ const (
 type CalenderServiceClient interface {
 	GetCalendar(ctx context.Context, in *CalendarRequest, opts ...grpc.CallOption) (*CalendarResponse, error)
 	GetCurrentEvent(ctx context.Context, in *CalendarRequest, opts ...grpc.CallOption) (*CalendarEntry, error)
+	GetNextEvent(ctx context.Context, in *CalendarRequest, opts ...grpc.CallOption) (*CalendarEntry, error)
+	GetAvailability(ctx context.Context, in *CalendarRequest, opts ...grpc.CallOption) (*Availability, error)
+	GetFreeBusy(ctx context.Context, in *FreeBusyRequest, opts ...grpc.CallOption) (*FreeBusyResponse, error)
+	FindSlots(ctx context.Context, in *FindSlotsRequest, opts ...grpc.CallOption) (*FindSlotsResponse, error)
 	RefreshCalendar(ctx context.Context, in *CalendarRequest, opts ...grpc.CallOption) (*RefreshCalendarResponse, error)
+	ListCalendars(ctx context.Context, in *ListCalendarsRequest, opts ...grpc.CallOption) (*ListCalendarsResponse, error)
+	WatchCalendar(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchCalendarResponse], error)
+	WatchCurrentEvent(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchCurrentEventResponse], error)
 	GetCustomStatus(ctx context.Context, in *GetCustomStatusRequest, opts ...grpc.CallOption) (*CustomStatus, error)
 	SetCustomStatus(ctx context.Context, in *SetCustomStatusRequest, opts ...grpc.CallOption) (*CustomStatus, error)
 	ClearCustomStatus(ctx context.Context, in *ClearCustomStatusRequest, opts ...grpc.CallOption) (*CustomStatus, error)
@@ -67,6 +81,46 @@ func (c *calenderServiceClient) GetCurrentEvent(ctx context.Context, in *Calenda
 	return out, nil
 }
 
+func (c *calenderServiceClient) GetNextEvent(ctx context.Context, in *CalendarRequest, opts ...grpc.CallOption) (*CalendarEntry, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CalendarEntry)
+	err := c.cc.Invoke(ctx, CalenderService_GetNextEvent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calenderServiceClient) GetAvailability(ctx context.Context, in *CalendarRequest, opts ...grpc.CallOption) (*Availability, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Availability)
+	err := c.cc.Invoke(ctx, CalenderService_GetAvailability_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calenderServiceClient) GetFreeBusy(ctx context.Context, in *FreeBusyRequest, opts ...grpc.CallOption) (*FreeBusyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FreeBusyResponse)
+	err := c.cc.Invoke(ctx, CalenderService_GetFreeBusy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calenderServiceClient) FindSlots(ctx context.Context, in *FindSlotsRequest, opts ...grpc.CallOption) (*FindSlotsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FindSlotsResponse)
+	err := c.cc.Invoke(ctx, CalenderService_FindSlots_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *calenderServiceClient) RefreshCalendar(ctx context.Context, in *CalendarRequest, opts ...grpc.CallOption) (*RefreshCalendarResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(RefreshCalendarResponse)
@@ -77,6 +131,54 @@ func (c *calenderServiceClient) RefreshCalendar(ctx context.Context, in *Calenda
 	return out, nil
 }
 
+func (c *calenderServiceClient) ListCalendars(ctx context.Context, in *ListCalendarsRequest, opts ...grpc.CallOption) (*ListCalendarsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCalendarsResponse)
+	err := c.cc.Invoke(ctx, CalenderService_ListCalendars_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calenderServiceClient) WatchCalendar(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchCalendarResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &CalenderService_ServiceDesc.Streams[0], CalenderService_WatchCalendar_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchRequest, WatchCalendarResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CalenderService_WatchCalendarClient = grpc.ServerStreamingClient[WatchCalendarResponse]
+
+func (c *calenderServiceClient) WatchCurrentEvent(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchCurrentEventResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &CalenderService_ServiceDesc.Streams[1], CalenderService_WatchCurrentEvent_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchRequest, WatchCurrentEventResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CalenderService_WatchCurrentEventClient = grpc.ServerStreamingClient[WatchCurrentEventResponse]
+
 func (c *calenderServiceClient) GetCustomStatus(ctx context.Context, in *GetCustomStatusRequest, opts ...grpc.CallOption) (*CustomStatus, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(CustomStatus)
@@ -113,7 +215,14 @@ func (c *calenderServiceClient) ClearCustomStatus(ctx context.Context, in *Clear
 type CalenderServiceServer interface {
 	GetCalendar(context.Context, *CalendarRequest) (*CalendarResponse, error)
 	GetCurrentEvent(context.Context, *CalendarRequest) (*CalendarEntry, error)
+	GetNextEvent(context.Context, *CalendarRequest) (*CalendarEntry, error)
+	GetAvailability(context.Context, *CalendarRequest) (*Availability, error)
+	GetFreeBusy(context.Context, *FreeBusyRequest) (*FreeBusyResponse, error)
+	FindSlots(context.Context, *FindSlotsRequest) (*FindSlotsResponse, error)
 	RefreshCalendar(context.Context, *CalendarRequest) (*RefreshCalendarResponse, error)
+	ListCalendars(context.Context, *ListCalendarsRequest) (*ListCalendarsResponse, error)
+	WatchCalendar(*WatchRequest, grpc.ServerStreamingServer[WatchCalendarResponse]) error
+	WatchCurrentEvent(*WatchRequest, grpc.ServerStreamingServer[WatchCurrentEventResponse]) error
 	GetCustomStatus(context.Context, *GetCustomStatusRequest) (*CustomStatus, error)
 	SetCustomStatus(context.Context, *SetCustomStatusRequest) (*CustomStatus, error)
 	ClearCustomStatus(context.Context, *ClearCustomStatusRequest) (*CustomStatus, error)
@@ -133,9 +242,30 @@ func (UnimplementedCalenderServiceServer) GetCalendar(context.Context, *Calendar
 func (UnimplementedCalenderServiceServer) GetCurrentEvent(context.Context, *CalendarRequest) (*CalendarEntry, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetCurrentEvent not implemented")
 }
+func (UnimplementedCalenderServiceServer) GetNextEvent(context.Context, *CalendarRequest) (*CalendarEntry, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNextEvent not implemented")
+}
+func (UnimplementedCalenderServiceServer) GetAvailability(context.Context, *CalendarRequest) (*Availability, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAvailability not implemented")
+}
+func (UnimplementedCalenderServiceServer) GetFreeBusy(context.Context, *FreeBusyRequest) (*FreeBusyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFreeBusy not implemented")
+}
+func (UnimplementedCalenderServiceServer) FindSlots(context.Context, *FindSlotsRequest) (*FindSlotsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindSlots not implemented")
+}
 func (UnimplementedCalenderServiceServer) RefreshCalendar(context.Context, *CalendarRequest) (*RefreshCalendarResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RefreshCalendar not implemented")
 }
+func (UnimplementedCalenderServiceServer) ListCalendars(context.Context, *ListCalendarsRequest) (*ListCalendarsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCalendars not implemented")
+}
+func (UnimplementedCalenderServiceServer) WatchCalendar(*WatchRequest, grpc.ServerStreamingServer[WatchCalendarResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchCalendar not implemented")
+}
+func (UnimplementedCalenderServiceServer) WatchCurrentEvent(*WatchRequest, grpc.ServerStreamingServer[WatchCurrentEventResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchCurrentEvent not implemented")
+}
 func (UnimplementedCalenderServiceServer) GetCustomStatus(context.Context, *GetCustomStatusRequest) (*CustomStatus, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetCustomStatus not implemented")
 }
@@ -202,6 +332,78 @@ func _CalenderService_GetCurrentEvent_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CalenderService_GetNextEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CalendarRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalenderServiceServer).GetNextEvent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalenderService_GetNextEvent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalenderServiceServer).GetNextEvent(ctx, req.(*CalendarRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalenderService_GetAvailability_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CalendarRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalenderServiceServer).GetAvailability(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalenderService_GetAvailability_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalenderServiceServer).GetAvailability(ctx, req.(*CalendarRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalenderService_GetFreeBusy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FreeBusyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalenderServiceServer).GetFreeBusy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalenderService_GetFreeBusy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalenderServiceServer).GetFreeBusy(ctx, req.(*FreeBusyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalenderService_FindSlots_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindSlotsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalenderServiceServer).FindSlots(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalenderService_FindSlots_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalenderServiceServer).FindSlots(ctx, req.(*FindSlotsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _CalenderService_RefreshCalendar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CalendarRequest)
 	if err := dec(in); err != nil {
@@ -220,6 +422,46 @@ func _CalenderService_RefreshCalendar_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CalenderService_ListCalendars_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCalendarsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalenderServiceServer).ListCalendars(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalenderService_ListCalendars_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalenderServiceServer).ListCalendars(ctx, req.(*ListCalendarsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalenderService_WatchCalendar_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CalenderServiceServer).WatchCalendar(m, &grpc.GenericServerStream[WatchRequest, WatchCalendarResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CalenderService_WatchCalendarServer = grpc.ServerStreamingServer[WatchCalendarResponse]
+
+func _CalenderService_WatchCurrentEvent_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CalenderServiceServer).WatchCurrentEvent(m, &grpc.GenericServerStream[WatchRequest, WatchCurrentEventResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CalenderService_WatchCurrentEventServer = grpc.ServerStreamingServer[WatchCurrentEventResponse]
+
 func _CalenderService_GetCustomStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetCustomStatusRequest)
 	if err := dec(in); err != nil {
@@ -289,10 +531,30 @@ var CalenderService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetCurrentEvent",
 			Handler:    _CalenderService_GetCurrentEvent_Handler,
 		},
+		{
+			MethodName: "GetNextEvent",
+			Handler:    _CalenderService_GetNextEvent_Handler,
+		},
+		{
+			MethodName: "GetAvailability",
+			Handler:    _CalenderService_GetAvailability_Handler,
+		},
+		{
+			MethodName: "GetFreeBusy",
+			Handler:    _CalenderService_GetFreeBusy_Handler,
+		},
+		{
+			MethodName: "FindSlots",
+			Handler:    _CalenderService_FindSlots_Handler,
+		},
 		{
 			MethodName: "RefreshCalendar",
 			Handler:    _CalenderService_RefreshCalendar_Handler,
 		},
+		{
+			MethodName: "ListCalendars",
+			Handler:    _CalenderService_ListCalendars_Handler,
+		},
 		{
 			MethodName: "GetCustomStatus",
 			Handler:    _CalenderService_GetCustomStatus_Handler,
@@ -306,6 +568,17 @@ var CalenderService_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _CalenderService_ClearCustomStatus_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchCalendar",
+			Handler:       _CalenderService_WatchCalendar_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchCurrentEvent",
+			Handler:       _CalenderService_WatchCurrentEvent_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "calendar.proto",
 }